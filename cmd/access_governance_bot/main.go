@@ -2,24 +2,20 @@ package main
 
 import (
 	"context"
-	"errors"
-	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"access_governance_system/configs"
 	"access_governance_system/internal/db"
 	"access_governance_system/internal/db/repositories"
 	"access_governance_system/internal/di"
-	"access_governance_system/internal/services"
+	"access_governance_system/internal/health"
 	tgbot "access_governance_system/internal/tg_bot"
 	"access_governance_system/internal/tg_bot/commands"
 	agbcommands "access_governance_system/internal/tg_bot/commands/access_governance_bot"
+	"access_governance_system/internal/tg_bot/handlers"
 	agbhandlers "access_governance_system/internal/tg_bot/handlers/access_governance_bot"
-
-	"go.uber.org/zap"
 )
 
 func main() {
@@ -38,57 +34,54 @@ func main() {
 	}
 	logger.Info("db started")
 
-	go func() {
-		logger.Info("setting up health check server")
-		settingUpHealthCheckServer(logger)
-	}()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger.Info("setting up health check server")
+	healthServer := health.NewServer("access-governance-bot", ":8080", logger)
+	healthServer.AddCheck("db", health.DBCheck(database))
+	if config.Votes.Source != configs.VotesSourceTelegram {
+		healthServer.AddCheck("vote_api", health.VoteAPICheck(config.VoteAPI.URL))
+	}
+	healthServer.AddCheck("telegram", health.TelegramCheck(config.AccessGovernanceBot.Token))
+	healthServer.Start()
 
 	logger.Info("starting bot")
 	userRepository := repositories.NewUserRepository(database)
 	proposalRepository := repositories.NewProposalRepository(database)
-	voteService := services.NewVoteService(config.VoteAPI.URL)
-
-	tgbot.NewBot(
-		agbhandlers.NewAccessGovernanceBotCommandHandler(
-			config, userRepository, proposalRepository, logger,
-			[]commands.Command{
-				agbcommands.NewStartCommand(config, userRepository, logger),
-				agbcommands.NewCancelProposalCommand(config.App, userRepository, logger),
-				agbcommands.NewApprovedProposalsCommand(proposalRepository, logger),
-				agbcommands.NewCreateProposalCommand(config, userRepository, proposalRepository, voteService, logger),
-				agbcommands.NewPendingProposalsCommand(userRepository, proposalRepository, logger),
-				agbcommands.NewAddCommentCommand(userRepository, proposalRepository, config.VoteBot, logger),
-			},
-		),
-	).Start(config.AccessGovernanceBot.Token, logger)
-}
-
-func settingUpHealthCheckServer(logger *zap.SugaredLogger) {
-	mux := http.NewServeMux()
-	mux.HandleFunc("/access-governance-bot/healthcheck", healthCheckHandler)
-
-	server := &http.Server{Addr: ":8080", Handler: mux}
-
-	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
-		logger.Errorw("failed to start http server", "error", err)
+	proposalResultRepository := repositories.NewProposalResultRepository(database)
+	proposalBlockRepository := repositories.NewProposalBlockRepository(database)
+	voteService := di.NewVoteService(database, config.VoteAPI, config.Votes, config.AccessGovernanceBot, config.App.SeedersChatID)
+
+	var handler handlers.CommandHandler = agbhandlers.NewAccessGovernanceBotCommandHandler(
+		config, userRepository, proposalRepository, logger,
+		[]commands.Command{
+			agbcommands.NewStartCommand(config, userRepository, logger),
+			agbcommands.NewCancelProposalCommand(config.App, userRepository, logger),
+			agbcommands.NewApprovedProposalsCommand(proposalRepository, proposalResultRepository, logger),
+			agbcommands.NewCreateProposalCommand(config, userRepository, proposalRepository, voteService, logger),
+			agbcommands.NewPendingProposalsCommand(userRepository, proposalRepository, logger),
+			agbcommands.NewAddCommentCommand(userRepository, proposalRepository, config.VoteBot, logger),
+			agbcommands.NewBlockProposalCommand(userRepository, proposalRepository, proposalBlockRepository, config.VoteBot, logger),
+			agbcommands.NewWithdrawProposalCommand(userRepository, proposalRepository, voteService, logger),
+			agbcommands.NewMyProposalsCommand(userRepository, proposalResultRepository, logger),
+		},
+	)
+	if config.Votes.Source == configs.VotesSourceTelegram {
+		handler = handlers.NewPollAnswerHandler(
+			handler,
+			repositories.NewTelegramPollRepository(database),
+			repositories.NewVoteRepository(database),
+			logger,
+		)
 	}
 
-	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
-	<-stop
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	err := server.Shutdown(ctx)
-	if err != nil {
-		logger.Errorw("failed to shutdown http server", "error", err)
-		return
-	}
+	tgbot.NewBot(handler).Start(ctx, config.AccessGovernanceBot.Token, logger)
 
 	logger.Info("shutting down")
-}
+	healthServer.Shutdown()
 
-func healthCheckHandler(w http.ResponseWriter, r *http.Request) {
-	_, _ = w.Write([]byte("I'm alive"))
+	if err = database.Close(); err != nil {
+		logger.Errorw("failed to close db", "error", err)
+	}
 }
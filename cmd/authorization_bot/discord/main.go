@@ -4,14 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"access_governance_system/configs"
 	"access_governance_system/internal/di"
+	"access_governance_system/internal/health"
 
 	"github.com/bwmarrin/discordgo"
 	"go.uber.org/zap"
@@ -31,11 +30,6 @@ func main() {
 	}
 	logger.Info("config loaded")
 
-	go func() {
-		logger.Info("setting up health check server")
-		settingUpHealthCheckServer(logger)
-	}()
-
 	logger.Info("starting bot")
 
 	discord, err := discordgo.New("Bot " + config.AuthrozationBot.Token)
@@ -53,10 +47,22 @@ func main() {
 		return
 	}
 
+	logger.Info("setting up health check server")
+	healthServer := health.NewServer("authorization-bot-discord", ":8080", logger)
+	healthServer.AddCheck("discord", func(ctx context.Context) (interface{}, error) {
+		if !discord.DataReady {
+			return nil, errors.New("discord session is not ready")
+		}
+		return nil, nil
+	})
+	healthServer.Start()
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 	<-stop
 
+	logger.Info("shutting down")
+	healthServer.Shutdown()
 	discord.Close()
 }
 
@@ -82,33 +88,3 @@ func authorization(s *discordgo.Session, m *discordgo.MessageCreate) {
 		fmt.Println("failed to send message", "error", err)
 	}
 }
-
-func settingUpHealthCheckServer(logger *zap.SugaredLogger) {
-	mux := http.NewServeMux()
-	mux.HandleFunc("/authorization-bot-discord/healthcheck", healthCheckHandler)
-
-	server := &http.Server{Addr: ":8080", Handler: mux}
-
-	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
-		logger.Errorw("failed to start http server", "error", err)
-	}
-
-	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
-	<-stop
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	err := server.Shutdown(ctx)
-	if err != nil {
-		logger.Errorw("failed to shutdown http server", "error", err)
-		return
-	}
-
-	logger.Info("shutting down")
-}
-
-func healthCheckHandler(w http.ResponseWriter, r *http.Request) {
-	_, _ = w.Write([]byte("I'm alive"))
-}
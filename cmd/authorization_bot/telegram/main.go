@@ -2,23 +2,19 @@ package main
 
 import (
 	"context"
-	"errors"
-	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"access_governance_system/configs"
 	"access_governance_system/internal/db"
 	"access_governance_system/internal/db/repositories"
 	"access_governance_system/internal/di"
+	"access_governance_system/internal/health"
 	tgbot "access_governance_system/internal/tg_bot"
 	"access_governance_system/internal/tg_bot/commands"
 	abcommands "access_governance_system/internal/tg_bot/commands/authorization_bot"
 	abhandlers "access_governance_system/internal/tg_bot/handlers/authorization_bot"
-
-	"go.uber.org/zap"
 )
 
 func main() {
@@ -37,10 +33,14 @@ func main() {
 	}
 	logger.Info("db started")
 
-	go func() {
-		logger.Info("setting up health check server")
-		settingUpHealthCheckServer(logger)
-	}()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger.Info("setting up health check server")
+	healthServer := health.NewServer("authorization-bot-telegram", ":8080", logger)
+	healthServer.AddCheck("db", health.DBCheck(database))
+	healthServer.AddCheck("telegram", health.TelegramCheck(config.TelegramAuthrozationBot.Token))
+	healthServer.Start()
 
 	logger.Info("starting bot")
 	userRepository := repositories.NewUserRepository(database)
@@ -52,35 +52,12 @@ func main() {
 				abcommands.NewStartCommand(config.DiscordAuthrozationBot, userRepository, logger),
 			},
 		),
-	).Start(config.TelegramAuthrozationBot.Token, logger)
-}
-
-func settingUpHealthCheckServer(logger *zap.SugaredLogger) {
-	mux := http.NewServeMux()
-	mux.HandleFunc("/authorization-bot-telegram/healthcheck", healthCheckHandler)
-
-	server := &http.Server{Addr: ":8080", Handler: mux}
-
-	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
-		logger.Errorw("failed to start http server", "error", err)
-	}
-
-	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
-	<-stop
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	err := server.Shutdown(ctx)
-	if err != nil {
-		logger.Errorw("failed to shutdown http server", "error", err)
-		return
-	}
+	).Start(ctx, config.TelegramAuthrozationBot.Token, logger)
 
 	logger.Info("shutting down")
-}
+	healthServer.Shutdown()
 
-func healthCheckHandler(w http.ResponseWriter, r *http.Request) {
-	_, _ = w.Write([]byte("I'm alive"))
+	if err = database.Close(); err != nil {
+		logger.Errorw("failed to close db", "error", err)
+	}
 }
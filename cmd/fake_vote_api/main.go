@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"access_governance_system/internal/di"
+	"access_governance_system/internal/fakevoteapi"
+)
+
+const shutdownTimeout = 5 * time.Second
+
+// The fake vote API keeps the polls in memory, they are lost on restart.
+func main() {
+	logger := di.NewLogger()
+
+	flags := flag.NewFlagSet("fake_vote_api", flag.ExitOnError)
+	addr := flags.String("addr", ":8000", "the address to listen on")
+	chatID := flags.Int("chat-id", 0, "the chat the polls are reported as posted to")
+	webhookURL := flags.String("webhook-url", "", "the vote webhook the vote events are posted to, if any")
+	webhookSecret := flags.String("webhook-secret", "", "the secret the vote events are signed with")
+	_ = flags.Parse(os.Args[1:])
+
+	fakeVoteAPI := fakevoteapi.NewServer(*chatID)
+	if *webhookURL != "" {
+		fakeVoteAPI.SetWebhook(*webhookURL, *webhookSecret)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           fakeVoteAPI,
+		ReadHeaderTimeout: shutdownTimeout,
+	}
+
+	go func() {
+		if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			logger.Fatalw("failed to start http server", "error", err)
+		}
+	}()
+	logger.Infow("fake vote api started", "addr", *addr)
+
+	<-ctx.Done()
+	logger.Info("shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Errorw("failed to shutdown http server", "error", err)
+	}
+}
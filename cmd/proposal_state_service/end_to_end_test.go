@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"access_governance_system/configs"
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/db/repositories/mocks"
+	"access_governance_system/internal/fakevoteapi"
+	"access_governance_system/internal/services"
+	agbcommands "access_governance_system/internal/tg_bot/commands/access_governance_bot"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+const seedersChatID = -100
+
+// TestProposalApprovedByVotes creates a proposal with the access governance bot against the fake vote API,
+// votes for it as every seeder and runs the proposal state service on it until its poll is closed.
+func TestProposalApprovedByVotes(t *testing.T) {
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+	ctrl := gomock.NewController(t)
+
+	fakeVoteAPI, voteAPIServer := fakevoteapi.NewTestServer(seedersChatID)
+	defer voteAPIServer.Close()
+
+	voteService := services.NewVoteService(configs.VoteAPI{URL: voteAPIServer.URL, Timeout: time.Second})
+
+	app := configs.App{VotingDurationDays: 7, MembersChatID: -200, SeedersChatID: seedersChatID, Timezone: configs.Location{Location: time.UTC}}
+	policies := configs.Policies{Member: configs.Policy{
+		Quorum:                  0.5,
+		MaxRequiredSeedersCount: 10,
+		MinYesVotesPercentage:   0.5,
+		MinRequiredYesVotes:     2,
+		YesVotesToOvercomeNo:    0.5,
+	}}
+
+	nominator := &models.User{ID: 1, TelegramID: 10, TelegramNickname: "nominator", Role: models.UserRoleMember}
+	seeders := []*models.User{
+		{ID: 2, TelegramID: 20, TelegramNickname: "seeder1", Role: models.UserRoleSeeder},
+		{ID: 3, TelegramID: 30, TelegramNickname: "seeder2", Role: models.UserRoleSeeder},
+		{ID: 4, TelegramID: 40, TelegramNickname: "seeder3", Role: models.UserRoleSeeder},
+	}
+
+	userRepository := mock_repositories.NewMockUserRepository(ctrl)
+	proposalRepository := mock_repositories.NewMockProposalRepository(ctrl)
+	proposalBlockRepository := mock_repositories.NewMockProposalBlockRepository(ctrl)
+
+	userRepository.EXPECT().Update(gomock.Any()).Return(nominator, nil).AnyTimes()
+	userRepository.EXPECT().GetManyByRole(models.UserRoleSeeder).Return(seeders, nil).AnyTimes()
+	userRepository.EXPECT().GetOneByID(nominator.ID).Return(nominator, nil).AnyTimes()
+	userRepository.EXPECT().GetOneByTelegramNickname("nominee").Return(nil, nil).AnyTimes()
+	userRepository.EXPECT().GetOneByTelegramID(gomock.Any()).DoAndReturn(func(telegramID int64) (*models.User, error) {
+		for _, seeder := range seeders {
+			if seeder.TelegramID == telegramID {
+				return seeder, nil
+			}
+		}
+		return nil, nil
+	}).AnyTimes()
+	proposalBlockRepository.EXPECT().GetManyByProposalID(gomock.Any()).Return(nil, nil).AnyTimes()
+
+	var proposal *models.Proposal
+	proposalRepository.EXPECT().Create(gomock.Any()).DoAndReturn(func(request *models.Proposal) (*models.Proposal, error) {
+		created := *request
+		created.ID = 1
+		proposal = &created
+		return proposal, nil
+	})
+
+	createProposal := agbcommands.NewCreateProposalCommand(
+		configs.AccessGovernanceBotConfig{App: app, Policies: policies},
+		userRepository,
+		proposalRepository,
+		voteService,
+		logger,
+	)
+
+	nominator.TelegramState = models.TelegramState{LastCommand: "create_proposal", LastCommandState: "waiting_for_confirm"}
+	nominator.TempProposal = models.Proposal{
+		NominatorID:             nominator.ID,
+		NomineeTelegramNickname: "nominee",
+		NomineeName:             "Nominee",
+		NomineeRole:             models.NomineeRoleMember,
+		Comment:                 "a good person",
+	}
+	createProposal.Handle("Да", "", nominator, newTestBot(t), nominator.TelegramID)
+
+	if proposal == nil {
+		t.Fatal("proposal is not created")
+	}
+
+	poll, ok := fakeVoteAPI.Poll(proposal.Poll.ID)
+	if !ok {
+		t.Fatalf("poll %d is not created", proposal.Poll.ID)
+	} else if !poll.DueDate.Equal(proposal.FinishedAt) {
+		t.Errorf("poll is due %s, want %s", poll.DueDate, proposal.FinishedAt)
+	}
+
+	for _, seeder := range seeders {
+		if err := fakeVoteAPI.Vote(proposal.Poll.ID, seeder.TelegramID, services.VoteOptionYes); err != nil {
+			t.Fatalf("failed to vote: %v", err)
+		}
+	}
+
+	config := configs.ProposalStateServiceConfig{App: app, Policies: policies}
+
+	updates, failedCount := checkProposals(
+		ctx, seeders, []*models.Proposal{proposal}, voteService, userRepository, proposalBlockRepository, config, logger,
+	)
+	if failedCount != 0 || len(updates) != 1 {
+		t.Fatalf("checked %d proposals with %d failed, want 1 with none failed", len(updates), failedCount)
+	} else if updates[0].waiting {
+		t.Fatal("proposal is still waiting after every seeder voted")
+	}
+
+	proposalRepository.EXPECT().
+		Finalize(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			request *models.Proposal,
+			result *models.ProposalResult,
+			nominee *models.User,
+			messages []*models.OutboxMessage,
+		) (*models.Proposal, error) {
+			if request.Status != models.ProposalStatusApproved {
+				t.Errorf("proposal is %s, want approved", request.Status)
+			}
+			if result.YesVotes != len(seeders) || !result.DecidedEarly {
+				t.Errorf("result has %d yes votes, decided early %t, want %d decided early", result.YesVotes, result.DecidedEarly, len(seeders))
+			}
+			if nominee == nil || nominee.TelegramNickname != "nominee" || len(nominee.BackersID) != len(seeders) {
+				t.Errorf("nominee is %+v, want nominee backed by every seeder", nominee)
+			}
+			return request, nil
+		})
+
+	run := &models.JobRun{}
+	updateProposals(ctx, updates, proposalRepository, userRepository, voteService, config, run, logger)
+	if run.Processed != 1 || run.Failed != 0 {
+		t.Fatalf("updated %d proposals with %d failed, want 1 with none failed", run.Processed, run.Failed)
+	}
+
+	proposalRepository.EXPECT().GetManyWithOpenPoll().Return([]*models.Proposal{proposal}, nil)
+	proposalRepository.EXPECT().MarkPollClosed(proposal).Return(nil)
+
+	closePolls(ctx, proposalRepository, voteService, logger)
+
+	if poll, _ = fakeVoteAPI.Poll(proposal.Poll.ID); !poll.Closed {
+		t.Error("poll is not closed")
+	}
+}
+
+// newTestBot returns a bot whose requests are answered by a stub of the Telegram Bot API.
+func newTestBot(t *testing.T) *tgbotapi.BotAPI {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/getMe") {
+			_, _ = w.Write([]byte(`{"ok": true, "result": {"id": 1, "is_bot": true, "first_name": "bot", "username": "bot"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok": true, "result": {"message_id": 1, "chat": {"id": 1}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	bot, err := tgbotapi.NewBotAPIWithClient("token", server.URL+"/bot%s/%s", server.Client())
+	if err != nil {
+		t.Fatalf("failed to create bot: %v", err)
+	}
+	return bot
+}
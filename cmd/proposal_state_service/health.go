@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/db/repositories"
+	"access_governance_system/internal/health"
+)
+
+type proposalsJobDetails struct {
+	LastRun           *models.JobRun `json:"last_run"`
+	LastSuccessfulRun *models.JobRun `json:"last_successful_run"`
+}
+
+// proposalsJobCheck reports the last run of the proposals job and the last one which succeeded,
+// a failed run does not make the service unready, the next run retries it.
+func proposalsJobCheck(jobRunRepository repositories.JobRunRepository) health.Check {
+	return func(ctx context.Context) (interface{}, error) {
+		var details proposalsJobDetails
+		var err error
+
+		details.LastRun, err = jobRunRepository.GetLastByName(proposalsJobName)
+		if err != nil {
+			return nil, err
+		}
+
+		details.LastSuccessfulRun, err = jobRunRepository.GetLastByName(proposalsJobName, models.JobRunStatusSucceeded)
+		if err != nil {
+			return nil, err
+		}
+
+		return details, nil
+	}
+}
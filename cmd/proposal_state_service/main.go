@@ -1,24 +1,48 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
-	"math"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"access_governance_system/configs"
+	"access_governance_system/internal"
 	"access_governance_system/internal/db"
 	"access_governance_system/internal/db/models"
 	"access_governance_system/internal/db/repositories"
 	"access_governance_system/internal/di"
+	"access_governance_system/internal/health"
+	"access_governance_system/internal/metrics"
+	"access_governance_system/internal/outbox"
+	"access_governance_system/internal/policy"
 	"access_governance_system/internal/services"
-	tgbot "access_governance_system/internal/tg_bot/extension"
+	"access_governance_system/internal/webhook"
 	"github.com/go-co-op/gocron"
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/go-pg/pg/v10"
 	"go.uber.org/zap"
 )
 
+const (
+	proposalsJobName = "proposals"
+	voteWebhookPath  = "/proposal-state-service/votes"
+)
+
 func main() {
-	s := gocron.NewScheduler(time.UTC)
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case simulateCommandName:
+			runSimulation(os.Args[2:])
+			return
+		case whatIfCommandName:
+			runWhatIf(os.Args[2:])
+			return
+		}
+	}
 
 	config, err := configs.LoadProposalStateServiceConfig()
 	logger := di.NewLogger()
@@ -28,6 +52,9 @@ func main() {
 	}
 	logger.Info("config loaded")
 
+	s := gocron.NewScheduler(config.App.Timezone.Location)
+	s.SingletonModeAll()
+
 	logger.Info("starting db")
 	database, err := db.StartDB(config.DB, logger)
 	if err != nil {
@@ -35,470 +62,610 @@ func main() {
 	}
 	logger.Info("db started")
 
-	s.Cron("10 12 * * *").Do(
-		func() {
-			logger.Info("initializing repositories and services")
-			userRepository := repositories.NewUserRepository(database)
-			proposalRepository := repositories.NewProposalRepository(database)
-			voteService := services.NewVoteService(config.VoteAPI.URL)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-			logger.Info("getting seeders")
-			seeders, err := userRepository.GetManyByRole(models.UserRoleSeeder)
-			if err != nil {
-				logger.Fatalw("failed to get seeders", "error", err)
-			}
+	jobRunRepository := repositories.NewJobRunRepository(database)
+	proposalRepository := repositories.NewProposalRepository(database)
+	voteService := newVoteService(database, config)
 
-			logger.Info("getting proposals")
-			proposals, err := proposalRepository.GetManyByStatus(models.ProposalStatusCreated)
+	proposalsJob, err := s.Cron(config.Schedule).Do(
+		func() {
+			unlock, locked, err := db.TryLock(database, db.ProposalStateServiceLockKey)
 			if err != nil {
-				logger.Fatalw("failed to get proposals", "error", err)
+				logger.Errorw("failed to take lock", "error", err)
+				return
+			} else if !locked {
+				logger.Info("proposals are being checked by another instance")
+				return
 			}
+			defer func() {
+				if err := unlock(); err != nil {
+					logger.Errorw("failed to release lock", "error", err)
+				}
+			}()
 
-			proposalsNeedToBeUpdated := getProposalsNeedToBeUpdated(
-				seeders,
-				proposals,
-				voteService,
-				userRepository,
-				config,
-				logger,
-			)
+			run := runProposalsJob(ctx, database, voteService, config, logger)
+			if err := jobRunRepository.Create(run); err != nil {
+				logger.Errorw("failed to save job run", "error", err)
+			}
+			metrics.ObserveJobRun(run)
 
-			if len(proposalsNeedToBeUpdated) == 0 {
-				logger.Info("no proposals to update")
+			if counts, err := proposalRepository.CountByStatus(); err != nil {
+				logger.Errorw("failed to count proposals", "error", err)
 			} else {
-				updatedProposals := updateProposals(
-					proposalsNeedToBeUpdated,
-					proposalRepository,
-					voteService,
-					userRepository,
-					logger,
-				)
-
-				for _, proposal := range updatedProposals {
-					sendNotifications(proposal, userRepository, config, logger)
-				}
-
-				logger.Info("proposals updated")
+				metrics.SetProposals(counts)
 			}
+
+			logger.Infow(
+				"proposals job finished",
+				"status", run.Status,
+				"processed", run.Processed,
+				"failed", run.Failed,
+				"skipped", run.Skipped,
+			)
 		},
 	)
+	if err != nil {
+		logger.Fatalw("failed to schedule job", "error", err, "schedule", config.Schedule)
+	}
 
-	s.StartBlocking()
-}
+	dispatcher := outbox.NewDispatcher(
+		repositories.NewOutboxMessageRepository(database),
+		config.AccessGovernanceBot.Token,
+		config.Outbox,
+		logger,
+	)
 
-func getProposalsNeedToBeUpdated(
-	seeders []*models.User,
-	proposals []*models.Proposal,
-	voteService services.VoteService,
-	userRepository repositories.UserRepository,
-	config configs.ProposalStateServiceConfig,
-	logger *zap.SugaredLogger,
-) []*models.Proposal {
-	var proposalsToUpdate []*models.Proposal
+	_, err = s.Every(config.Outbox.DispatchInterval).Do(
+		func() {
+			unlock, locked, err := db.TryLock(database, db.OutboxDispatcherLockKey)
+			if err != nil {
+				logger.Errorw("failed to take lock", "error", err)
+				return
+			} else if !locked {
+				return
+			}
+			defer func() {
+				if err := unlock(); err != nil {
+					logger.Errorw("failed to release lock", "error", err)
+				}
+			}()
 
-	minRequiredSeedersCount := calculateMinRequiredSeedersCount(len(seeders), config)
-	minRequiredYesVotesToOverride := calculateMinRequiredYesVotesToOverride(len(seeders), config)
+			dispatcher.Dispatch()
+		},
+	)
+	if err != nil {
+		logger.Fatalw("failed to schedule outbox dispatcher", "error", err)
+	}
 
-	for _, proposal := range proposals {
-		if proposalIsEligibleForUpdate(
-			proposal,
-			voteService,
-			userRepository,
+	logger.Info("setting up health check server")
+	healthServer := health.NewServer("proposal-state-service", ":8080", logger)
+	healthServer.AddCheck("db", health.DBCheck(database))
+	if config.Votes.Source != configs.VotesSourceTelegram {
+		healthServer.AddCheck("vote_api", health.VoteAPICheck(config.VoteAPI.URL))
+	}
+	healthServer.AddCheck("telegram", health.TelegramCheck(config.AccessGovernanceBot.Token))
+	healthServer.AddCheck("scheduler", health.SchedulerCheck(s, proposalsJob))
+	healthServer.AddCheck("proposals_job", proposalsJobCheck(jobRunRepository))
+	if config.Votes.Source == configs.VotesSourceWebhook {
+		healthServer.Handle(voteWebhookPath, webhook.NewVoteHandler(
+			config.Votes.WebhookSecret,
+			repositories.NewVoteRepository(database),
 			logger,
-			minRequiredSeedersCount,
-			minRequiredYesVotesToOverride,
-			config,
-		) {
-			proposalsToUpdate = append(proposalsToUpdate, proposal)
-		}
+		))
 	}
+	healthServer.Start()
+
+	s.StartAsync()
+	logger.Info("scheduler started")
+
+	<-ctx.Done()
+	logger.Info("shutting down")
 
-	return proposalsToUpdate
+	// Stop waits for the running jobs to finish.
+	s.Stop()
+	healthServer.Shutdown()
+
+	if err = database.Close(); err != nil {
+		logger.Errorw("failed to close db", "error", err)
+	}
 }
 
-func proposalIsEligibleForUpdate(
-	proposal *models.Proposal,
-	voteService services.VoteService,
-	userRepository repositories.UserRepository,
-	logger *zap.SugaredLogger,
-	minRequiredSeedersCount, minRequiredYesVotesToOverride int,
-	config configs.ProposalStateServiceConfig,
-) bool {
-	logger.Infow("checking proposal", "proposal", proposal)
+// runSimulation logs to stderr, so the simulation output on stdout can be piped.
+func runSimulation(arguments []string) {
+	logger := di.NewStderrLogger()
 
-	if proposal.FinishedAt.After(time.Now()) {
-		logger.Infow("proposal is not finished yet", "proposal", proposal)
-		return false
+	options, err := parseSimulationOptions(arguments)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	} else if err != nil {
+		logger.Fatalw("failed to parse simulation options", "error", err)
 	}
 
-	votes, err := voteService.GetVotes(proposal.Poll.ID)
-	if err != nil {
-		logger.Errorw("Failed to get votes", "error", err, "proposal", proposal)
-		return false
-	}
-
-	yesVotes, noVotes := countVotes(votes)
-	votedSeedersCount := countVotedSeeders(votes, userRepository, logger)
-
-	logger.Infow(
-		"getting proposal status",
-		"proposal", proposal,
-		"yesVotes", yesVotes,
-		"noVotes", noVotes,
-		"votedSeedersCount", votedSeedersCount,
-		"minRequiredSeedersCount", minRequiredSeedersCount,
-		"minRequiredYesVotesToOverride", minRequiredYesVotesToOverride,
-		"config", config,
-	)
-	proposal.Status = proposalStatus(
-		yesVotes,
-		noVotes,
-		votedSeedersCount,
-		minRequiredSeedersCount,
-		minRequiredYesVotesToOverride,
-		config,
-	)
-	logger.Infow("proposal status updated", "proposal", proposal)
+	config, database := connectForCommand(logger)
+	defer database.Close()
 
-	return true
+	if err = simulate(context.Background(), os.Stdout, options, database, config, logger); err != nil {
+		logger.Fatalw("failed to simulate", "error", err)
+	}
 }
 
-func calculateMinRequiredSeedersCount(totalSeeders int, config configs.ProposalStateServiceConfig) int {
-	return int(math.Min(math.Round(float64(totalSeeders)*config.Quorum), config.MaxRequiredSeedersCount))
+// runWhatIf logs to stderr, so the report on stdout can be redirected to a file.
+func runWhatIf(arguments []string) {
+	logger := di.NewStderrLogger()
+
+	options, err := parseWhatIfOptions(arguments)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	} else if err != nil {
+		logger.Fatalw("failed to parse what-if options", "error", err)
+	}
+
+	config, database := connectForCommand(logger)
+	defer database.Close()
+
+	if err = whatIf(context.Background(), os.Stdout, options, database, config, logger); err != nil {
+		logger.Fatalw("failed to replay proposals", "error", err)
+	}
 }
 
-func calculateMinRequiredYesVotesToOverride(totalSeeders int, config configs.ProposalStateServiceConfig) int {
-	return int(math.Round(float64(totalSeeders) * config.YesVotesToOvercomeNo))
+func newVoteService(database *pg.DB, config configs.ProposalStateServiceConfig) services.VoteService {
+	return di.NewVoteService(database, config.VoteAPI, config.Votes, config.AccessGovernanceBot, config.App.SeedersChatID)
 }
 
-func countVotes(votes []services.Vote) (yesVotes, noVotes int) {
-	for _, vote := range votes {
-		if vote.Option == "yes" {
-			yesVotes++
-		} else if vote.Option == "no" {
-			noVotes++
-		}
+// connectForCommand connects to the db without migrating it, the commands only read.
+func connectForCommand(logger *zap.SugaredLogger) (configs.ProposalStateServiceConfig, *pg.DB) {
+	config, err := configs.LoadProposalStateServiceConfig()
+	if err != nil {
+		logger.Fatalw("failed to load config", "error", err)
 	}
-	return yesVotes, noVotes
-}
 
-func countVotedSeeders(votes []services.Vote, userRepository repositories.UserRepository, logger *zap.SugaredLogger) int {
-	votedSeedersCount := 0
-	for _, vote := range votes {
-		user, err := userRepository.GetOneByTelegramID(vote.UserID)
-		if err != nil || user == nil {
-			continue
-		}
-		if user.Role == models.UserRoleSeeder {
-			votedSeedersCount++
-		}
+	database, err := db.ConnectDB(config.DB, logger)
+	if err != nil {
+		logger.Fatalw("failed to connect to db", "error", err)
 	}
-	return votedSeedersCount
+
+	return config, database
+}
+
+// proposalUpdate is a checked proposal with the votes and the decision it was checked against,
+// a waiting proposal is still being voted on and is left as it is.
+type proposalUpdate struct {
+	proposal *models.Proposal
+	votes    []services.Vote
+	tally    policy.Tally
+	decision policy.Decision
+	result   *models.ProposalResult
+
+	waiting         bool
+	extended        bool
+	notVotedSeeders []*models.User
 }
 
-func proposalStatus(
-	yesVotes, noVotes, votedSeedersCount, minRequiredSeedersCount, minRequiredYesVotesToOverride int,
+// runProposalsJob finalizes, extends and reminds about the created proposals.
+// A proposal which fails is counted and left for the next run, it does not stop the others.
+// The vote API calls are cancelled with ctx, so a shutdown does not wait for a hanging API.
+func runProposalsJob(
+	ctx context.Context,
+	database *pg.DB,
+	voteService services.VoteService,
 	config configs.ProposalStateServiceConfig,
-) models.ProposalStatus {
-	minRequiredYesVotes := int(
-		math.Max(
-			math.Round(float64(yesVotes+noVotes)*config.MinYesVotesPercentage),
-			config.MinRequiredYesVotes,
-		),
+	logger *zap.SugaredLogger,
+) *models.JobRun {
+	run := &models.JobRun{Name: proposalsJobName, StartedAt: time.Now()}
+
+	logger.Info("initializing repositories and services")
+	userRepository := repositories.NewUserRepository(database)
+	proposalRepository := repositories.NewProposalRepository(database)
+	proposalReminderRepository := repositories.NewProposalReminderRepository(database)
+	proposalBlockRepository := repositories.NewProposalBlockRepository(database)
+
+	logger.Info("getting seeders")
+	seeders, err := userRepository.GetManyByRole(models.UserRoleSeeder)
+	if err != nil {
+		logger.Errorw("failed to get seeders", "error", err)
+		return finishJobRun(run, err)
+	}
+
+	logger.Info("getting proposals")
+	proposals, err := proposalRepository.GetManyByStatus(models.ProposalStatusCreated)
+	if err != nil {
+		logger.Errorw("failed to get proposals", "error", err)
+		return finishJobRun(run, err)
+	}
+
+	updates, failedCount := checkProposals(
+		ctx,
+		seeders,
+		proposals,
+		voteService,
+		userRepository,
+		proposalBlockRepository,
+		config,
+		logger,
 	)
+	run.Failed += failedCount
+
+	var proposalsNeedToBeUpdated, waitingProposals []*proposalUpdate
+	for _, update := range updates {
+		if update.waiting {
+			run.Skipped++
+			waitingProposals = append(waitingProposals, update)
+		} else {
+			proposalsNeedToBeUpdated = append(proposalsNeedToBeUpdated, update)
+		}
+	}
 
-	if votedSeedersCount < minRequiredSeedersCount {
-		return models.ProposalStatusNoQuorum
-	} else if yesVotes < minRequiredYesVotes || (noVotes > 0 && yesVotes < minRequiredYesVotesToOverride) {
-		return models.ProposalStatusRejected
+	if len(proposalsNeedToBeUpdated) == 0 {
+		logger.Info("no proposals to update")
 	} else {
-		return models.ProposalStatusApproved
+		updatedProposals := updateProposals(
+			ctx,
+			proposalsNeedToBeUpdated,
+			proposalRepository,
+			userRepository,
+			voteService,
+			config,
+			run,
+			logger,
+		)
+
+		logger.Infow("proposals updated", "count", len(updatedProposals))
+	}
+
+	logger.Info("closing polls")
+	closePolls(ctx, proposalRepository, voteService, logger)
+
+	logger.Info("queueing reminders")
+	queueReminders(
+		waitingProposals,
+		seeders,
+		proposalReminderRepository,
+		config,
+		logger,
+	)
+
+	return finishJobRun(run, nil)
+}
+
+// finishJobRun marks the run as failed if it could not run at all or any proposal failed.
+func finishJobRun(run *models.JobRun, err error) *models.JobRun {
+	run.FinishedAt = time.Now()
+	run.Status = models.JobRunStatusSucceeded
+
+	if err != nil {
+		run.Status = models.JobRunStatusFailed
+		run.Error = err.Error()
+	} else if run.Failed > 0 {
+		run.Status = models.JobRunStatusFailed
 	}
+
+	return run
 }
 
-func updateProposals(
+// isolated runs f and turns a panic into an error, so a single proposal cannot stop the whole run.
+func isolated(f func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return f()
+}
+
+// checkProposals decides every proposal without changing anything,
+// proposals whose votes or blocks could not be fetched are left out and counted as failed.
+func checkProposals(
+	ctx context.Context,
+	seeders []*models.User,
 	proposals []*models.Proposal,
-	proposalRepository repositories.ProposalRepository,
 	voteService services.VoteService,
 	userRepository repositories.UserRepository,
+	proposalBlockRepository repositories.ProposalBlockRepository,
+	config configs.ProposalStateServiceConfig,
 	logger *zap.SugaredLogger,
-) []*models.Proposal {
-	var updatedProposals []*models.Proposal
+) ([]*proposalUpdate, int) {
+	var updates []*proposalUpdate
+	failedCount := 0
 
 	for _, proposal := range proposals {
-		_, err := proposalRepository.Update(proposal)
+		votingRules := policy.VotingRulesFor(proposal, config.App, config.Policies)
+		totalSeedersCount := totalSeedersCountFor(proposal, seeders)
+
+		var update *proposalUpdate
+		err := isolated(func() (err error) {
+			update, err = getProposalUpdate(
+				ctx,
+				proposal,
+				seeders,
+				voteService,
+				userRepository,
+				proposalBlockRepository,
+				logger,
+				votingRules,
+				totalSeedersCount,
+				config.App.Timezone.Location,
+			)
+			return err
+		})
 		if err != nil {
-			logger.Errorw("failed to update proposal", "error", err)
+			logger.Errorw("failed to check proposal", "error", err, "proposal", proposal)
+			failedCount++
 			continue
 		}
 
-		if proposal.Status == models.ProposalStatusApproved {
-			votes, err := voteService.GetVotes(proposal.Poll.ID)
-			if err != nil {
-				logger.Errorw("failed to get votes", "error", err)
-				continue
-			}
-
-			backersIDs := make([]int64, 0)
-			for _, vote := range votes {
-				if vote.Option == "yes" {
-					backersIDs = append(backersIDs, vote.UserID)
-				}
-			}
-
-			user, err := userRepository.GetOneByTelegramNickname(proposal.NomineeTelegramNickname)
-			if err != nil {
-				logger.Errorw("failed to get user", "error", err)
-				continue
-			} else if user != nil && proposal.NomineeRole == models.NomineeRoleSeeder {
-				user.BackersID = backersIDs
-				user.Role = models.UserRoleSeeder
-
-				_, err = userRepository.Update(user)
-				if err != nil {
-					logger.Errorw("failed to update user", "error", err)
-					continue
-				}
-			} else if user == nil {
-				user = &models.User{
-					Name:             proposal.NomineeName,
-					TelegramNickname: proposal.NomineeTelegramNickname,
-					Role:             models.UserRoleGuest,
-					BackersID:        backersIDs,
-				}
-
-				_, err = userRepository.Create(user)
-				if err != nil {
-					logger.Errorw("failed to create user", "error", err)
-					continue
-				}
-			}
-		}
-
-		updatedProposals = append(updatedProposals, proposal)
+		updates = append(updates, update)
 	}
 
-	return updatedProposals
+	return updates, failedCount
 }
 
-func sendNotifications(
+func getProposalUpdate(
+	ctx context.Context,
 	proposal *models.Proposal,
+	seeders []*models.User,
+	voteService services.VoteService,
 	userRepository repositories.UserRepository,
-	config configs.ProposalStateServiceConfig,
+	proposalBlockRepository repositories.ProposalBlockRepository,
 	logger *zap.SugaredLogger,
-) {
-	switch proposal.Status {
-	case models.ProposalStatusRejected:
-		sendNotificationsIfProposalRejected(proposal, userRepository, config, logger)
-	case models.ProposalStatusApproved:
-		sendNotificationsIfProposalApproved(proposal, userRepository, config, logger)
-	case models.ProposalStatusNoQuorum:
-		sendNotificationsIfProposalNoQuorum(proposal, userRepository, config, logger)
-	}
-}
+	votingRules models.VotingRules,
+	totalSeedersCount int,
+	location *time.Location,
+) (*proposalUpdate, error) {
+	logger.Infow("checking proposal", "proposal", proposal)
 
-func sendNotificationsIfProposalRejected(
-	proposal *models.Proposal,
-	userRepository repositories.UserRepository,
-	config configs.ProposalStateServiceConfig,
-	logger *zap.SugaredLogger,
-) {
-	nominator, err := userRepository.GetOneByID(proposal.NominatorID)
+	votes, err := voteService.GetVotes(ctx, proposal.Poll.ID)
 	if err != nil {
-		logger.Errorw("could not get nominator", "error", err)
-		return
+		return nil, fmt.Errorf("failed to get votes: %w", err)
 	}
 
-	bot, err := tgbotapi.NewBotAPI(config.AccessGovernanceBot.Token)
+	blocks, err := proposalBlockRepository.GetManyByProposalID(proposal.ID)
 	if err != nil {
-		logger.Errorw("could not create bot", "error", err)
-		return
+		return nil, fmt.Errorf("failed to get blocks: %w", err)
 	}
 
-	messages := []tgbotapi.MessageConfig{
-		messageForProposalRejectedToNominator(proposal, nominator),
-		messageForProposalRejectedToSeedersGroup(proposal),
-	}
+	tally := policy.CountVotes(votes)
+	tally.BlockVotes = len(blocks)
+	tally.VotedSeedersCount = countVotedSeeders(votes, userRepository, logger)
+	tally.TotalSeedersCount = totalSeedersCount
 
-	for _, message := range messages {
-		_, err = bot.Send(message)
-		if err != nil {
-			logger.Errorw("could not send message", "error", err)
+	logger.Infow("getting proposal status", "proposal", proposal, "tally", tally)
+
+	decisionPolicy := policy.NewDefaultPolicy(votingRules)
+
+	var decision policy.Decision
+
+	if now := time.Now(); proposal.FinishedAt.After(now) {
+		earlyDecision, decided := policy.DecideEarly(decisionPolicy, tally)
+		if !decided {
+			logger.Infow("proposal is not finished yet", "proposal", proposal)
+			return &proposalUpdate{
+				proposal: proposal,
+				votes:    votes,
+				tally:    tally,
+				decision: earlyDecision,
+				waiting:  true,
+			}, nil
 		}
-	}
-}
 
-func messageForProposalRejectedToNominator(proposal *models.Proposal, nominator *models.User) tgbotapi.MessageConfig {
-	text := fmt.Sprintf(
-		`
-Кандидатура %s (@%s) была отклонена.
+		logger.Infow("proposal is decided early", "proposal", proposal)
+		decision = earlyDecision
+		proposal.FinishedAt = now
+	} else {
+		decision = decisionPolicy.Decide(tally)
+
+		if decision.Status == models.ProposalStatusNoQuorum && canBeExtended(proposal, votingRules) {
+			proposal.FinishedAt = internal.VotingDeadline(now, votingRules.VotingExtensionDays, location)
+			proposal.ExtensionsCount++
+			logger.Infow("proposal voting extended", "proposal", proposal, "explanation", decision.Explanation)
+
+			return &proposalUpdate{
+				proposal:        proposal,
+				votes:           votes,
+				tally:           tally,
+				decision:        decision,
+				extended:        true,
+				notVotedSeeders: getNotVotedSeeders(seeders, votes),
+			}, nil
+		}
+	}
 
-_Это значит, что кворум не состоялся. Голосование по заявкам проходит анонимно в закрытой группе из активных участников сообщества, которые являются носителями ДНК. Повторную заявку на добавление этого человека можно отправить через 3 месяца._ 
-`,
-		proposal.NomineeName,
-		proposal.NomineeTelegramNickname,
-	)
-	message := tgbotapi.NewMessage(nominator.TelegramID, text)
-	message.ParseMode = tgbotapi.ModeMarkdown
-	return message
-}
+	proposal.Status = decision.Status
+	logger.Infow("proposal status updated", "proposal", proposal, "explanation", decision.Explanation)
 
-func messageForProposalRejectedToSeedersGroup(proposal *models.Proposal) tgbotapi.MessageConfig {
-	text := fmt.Sprintf(
-		"Кандидатура %s (@%s) была отклонена. Повторная заявка может быть создана через 3 месяца.",
-		proposal.NomineeName,
-		proposal.NomineeTelegramNickname,
-	)
-	message := tgbotapi.NewMessage(int64(proposal.Poll.ChatID), text)
-	message.BaseChat.ReplyToMessageID = proposal.Poll.PollMessageID
-	return message
+	return &proposalUpdate{
+		proposal: proposal,
+		votes:    votes,
+		tally:    tally,
+		decision: decision,
+		result:   policy.NewProposalResult(proposal, tally, decision, votes),
+	}, nil
 }
 
-func sendNotificationsIfProposalApproved(
-	proposal *models.Proposal,
-	userRepository repositories.UserRepository,
-	config configs.ProposalStateServiceConfig,
+// closePolls closes the polls of the finished proposals, so the votes cannot drift from the stored results.
+// A poll which could not be closed is tried again on the next run.
+func closePolls(
+	ctx context.Context,
+	proposalRepository repositories.ProposalRepository,
+	voteService services.VoteService,
 	logger *zap.SugaredLogger,
 ) {
-	nominator, err := userRepository.GetOneByID(proposal.NominatorID)
+	proposals, err := proposalRepository.GetManyWithOpenPoll()
 	if err != nil {
-		logger.Errorw("could not get nominator", "error", err)
+		logger.Errorw("failed to get proposals with open polls", "error", err)
 		return
 	}
 
-	bot, err := tgbotapi.NewBotAPI(config.AccessGovernanceBot.Token)
-	if err != nil {
-		logger.Errorw("could not create bot", "error", err)
-		return
+	for _, proposal := range proposals {
+		err = voteService.ClosePoll(ctx, proposal.Poll.ID)
+		if errors.Is(err, services.ErrNotFound) {
+			logger.Warnw("poll to close not found", "proposal", proposal)
+		} else if err != nil {
+			logger.Errorw("failed to close poll", "error", err, "proposal", proposal)
+			continue
+		}
+
+		if err = proposalRepository.MarkPollClosed(proposal); err != nil {
+			logger.Errorw("failed to mark poll closed", "error", err, "proposal", proposal)
+		}
 	}
+}
 
-	membersChatInviteLink, err := tgbot.CreateChatInviteLink(
-		bot,
-		config.App.MembersChatID,
-		nominator.TelegramNickname,
-		proposal.NomineeTelegramNickname,
-	)
-	if err != nil {
-		logger.Errorw("could not create members invite link", "error", err)
-		return
+// totalSeedersCountFor returns the seeders count the proposal was created with,
+// proposals created before it was snapshotted fall back to the current seeders.
+func totalSeedersCountFor(proposal *models.Proposal, seeders []*models.User) int {
+	if proposal.VotingRules == nil {
+		return len(seeders)
 	}
+	return proposal.SeedersCount
+}
 
-	seedersChatInviteLink, err := tgbot.CreateChatInviteLink(
-		bot,
-		config.App.SeedersChatID,
-		nominator.TelegramNickname,
-		proposal.NomineeTelegramNickname,
-	)
-	if err != nil {
-		logger.Errorw("could not create seeders invite link", "error", err)
-		return
+func canBeExtended(proposal *models.Proposal, votingRules models.VotingRules) bool {
+	return votingRules.VotingExtensionDays > 0 && proposal.ExtensionsCount < votingRules.MaxVotingExtensions
+}
+
+func getNotVotedSeeders(seeders []*models.User, votes []services.Vote) []*models.User {
+	votedUserIDs := make(map[int64]bool, len(votes))
+	for _, vote := range votes {
+		votedUserIDs[vote.UserID] = true
 	}
 
-	messages := messagesForProposalApprovedToNominator(proposal, nominator, membersChatInviteLink, seedersChatInviteLink)
+	notVotedSeeders := make([]*models.User, 0, len(seeders))
+	for _, seeder := range seeders {
+		if seeder.TelegramID != 0 && !votedUserIDs[seeder.TelegramID] {
+			notVotedSeeders = append(notVotedSeeders, seeder)
+		}
+	}
+	return notVotedSeeders
+}
 
-	for _, message := range messages {
-		_, err = bot.Send(message)
-		if err != nil {
-			logger.Errorw("could not send message", "error", err)
+func countVotedSeeders(votes []services.Vote, userRepository repositories.UserRepository, logger *zap.SugaredLogger) int {
+	votedSeedersCount := 0
+	for _, vote := range votes {
+		user, err := userRepository.GetOneByTelegramID(vote.UserID)
+		if err != nil || user == nil {
+			continue
+		}
+		if user.Role == models.UserRoleSeeder {
+			votedSeedersCount++
 		}
 	}
+	return votedSeedersCount
 }
 
-func messagesForProposalApprovedToNominator(
-	proposal *models.Proposal,
-	nominator *models.User,
-	membersChatInviteLink string,
-	seedersChatInviteLink string,
-) []tgbotapi.MessageConfig {
-	return []tgbotapi.MessageConfig{
-		func() tgbotapi.MessageConfig {
-			text := fmt.Sprintf(
-				`
-Кандидатура %s (@%s) была принята.
-
-Перешли ему следующее сообщение:
-`, proposal.NomineeName, proposal.NomineeTelegramNickname,
-			)
-			message := tgbotapi.NewMessage(nominator.TelegramID, text)
-			message.DisableWebPagePreview = true
-			return message
-		}(),
-		func() tgbotapi.MessageConfig {
-			var text string
-			switch proposal.NomineeRole {
-			case models.NomineeRoleMember:
-				text = fmt.Sprintf(
-					`
-Привет! Хочу тебя пригласить вступить в группу Shmit16. Я являюсь участником этого сообщества, и мне удалось получить одобрение на твое вступление. 
-
-Для того, чтобы войти в группу, перейди по [ссылке](%s) и нажми кнопку "Присоединиться".
-
-_Комьюнити Shmit16 выросло из группы IT-предпринимателей, которые собирались на бизнес-вечера по адресу Шмитовский проезд, 16. Спустя 10 лет сообщество насчитывает сотни людей разных специальностей по всему миру. Участие в сообществе бесплатное. Вступая в чат, тебе открывается доступ к мероприятиям и дискуссиям сообщества — фестивали, ретриты, онлайн и офлайн._ 
-`, membersChatInviteLink,
-				)
-			case models.NomineeRoleSeeder:
-				text = fmt.Sprintf(
-					`
-Привет! Тебя повысили до seeder. 
-
-Для того, чтобы войти в группу для сидеров, перейди по [ссылке](%s) и нажми кнопку "Присоединиться".
-`, seedersChatInviteLink,
-				)
-			}
-			message := tgbotapi.NewMessage(nominator.TelegramID, text)
-			message.ParseMode = tgbotapi.ModeMarkdown
-			message.DisableWebPagePreview = true
-			return message
-		}(),
+// updateProposals stores the updates with their notifications, a proposal
+// which was already finished by another run is skipped.
+func updateProposals(
+	ctx context.Context,
+	updates []*proposalUpdate,
+	proposalRepository repositories.ProposalRepository,
+	userRepository repositories.UserRepository,
+	voteService services.VoteService,
+	config configs.ProposalStateServiceConfig,
+	run *models.JobRun,
+	logger *zap.SugaredLogger,
+) []*proposalUpdate {
+	var updatedProposals []*proposalUpdate
+
+	for _, update := range updates {
+		err := isolated(func() error {
+			return updateProposal(ctx, update, proposalRepository, userRepository, voteService, config, logger)
+		})
+
+		switch {
+		case errors.Is(err, repositories.ErrProposalAlreadyFinished):
+			logger.Infow("proposal is already extended or finished", "proposal", update.proposal)
+			run.Skipped++
+		case err != nil:
+			logger.Errorw("failed to update proposal", "error", err, "proposal", update.proposal)
+			run.Failed++
+		default:
+			run.Processed++
+			updatedProposals = append(updatedProposals, update)
+		}
 	}
+
+	return updatedProposals
 }
 
-func sendNotificationsIfProposalNoQuorum(
-	proposal *models.Proposal,
+func updateProposal(
+	ctx context.Context,
+	update *proposalUpdate,
+	proposalRepository repositories.ProposalRepository,
 	userRepository repositories.UserRepository,
+	voteService services.VoteService,
 	config configs.ProposalStateServiceConfig,
 	logger *zap.SugaredLogger,
-) {
-	nominator, err := userRepository.GetOneByID(proposal.NominatorID)
+) error {
+	proposal := update.proposal
+
+	messages, err := notificationsFor(update, userRepository, config)
 	if err != nil {
-		logger.Errorw("could not get nominator", "error", err)
+		return fmt.Errorf("failed to build notifications: %w", err)
+	}
+
+	if update.extended {
+		// The poll is extended first, so a proposal is never extended while its poll stays closed at the old deadline,
+		// when the extension is not stored the proposal is extended again on the next run.
+		// A vote API which does not know the poll or cannot move its due date would fail every run,
+		// so the proposal is extended anyway and its deadline stays the one the votes are counted by.
+		err = voteService.ExtendPoll(ctx, proposal.Poll.ID, proposal.FinishedAt)
+		if errors.Is(err, services.ErrNotFound) || errors.Is(err, services.ErrBadRequest) {
+			logger.Warnw("poll due date not moved", "error", err, "proposal", proposal)
+		} else if err != nil {
+			return fmt.Errorf("failed to extend poll: %w", err)
+		}
+
+		if _, err = proposalRepository.Extend(proposal, messages); err != nil {
+			return fmt.Errorf("failed to extend proposal: %w", err)
+		}
+		return nil
 	}
 
-	bot, err := tgbotapi.NewBotAPI(config.AccessGovernanceBot.Token)
+	nominee, err := nomineeFor(update, userRepository)
 	if err != nil {
-		logger.Errorw("could not create bot", "error", err)
+		return err
 	}
 
-	messages := []tgbotapi.MessageConfig{
-		messageForProposalNoQuorumToNominator(proposal, nominator),
-		messageForProposalNoQuorumToSeedersGroup(proposal),
+	if _, err = proposalRepository.Finalize(proposal, update.result, nominee, messages); err != nil {
+		return fmt.Errorf("failed to finalize proposal: %w", err)
 	}
 
-	for _, message := range messages {
-		_, err = bot.Send(message)
-		if err != nil {
-			logger.Errorw("could not send message", "error", err)
+	return nil
+}
+
+// nomineeFor returns the user the approved proposal creates or promotes, it is saved together with the proposal,
+// nil when the proposal is not approved or a member is proposed who already is one.
+func nomineeFor(update *proposalUpdate, userRepository repositories.UserRepository) (*models.User, error) {
+	proposal := update.proposal
+	if proposal.Status != models.ProposalStatusApproved {
+		return nil, nil
+	}
+
+	backersIDs := make([]int64, 0)
+	for _, vote := range update.votes {
+		if vote.Option == services.VoteOptionYes {
+			backersIDs = append(backersIDs, vote.UserID)
 		}
 	}
-}
 
-func messageForProposalNoQuorumToNominator(proposal *models.Proposal, nominator *models.User) tgbotapi.MessageConfig {
-	text := fmt.Sprintf(
-		"Кандидатура %s (@%s) была отклонена по причине отсутствия кворума.",
-		proposal.NomineeName,
-		proposal.NomineeTelegramNickname,
-	)
-	message := tgbotapi.NewMessage(int64(nominator.TelegramID), text)
-	return message
-}
+	user, err := userRepository.GetOneByTelegramNickname(proposal.NomineeTelegramNickname)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	} else if user != nil && proposal.NomineeRole == models.NomineeRoleSeeder {
+		user.BackersID = backersIDs
+		user.Role = models.UserRoleSeeder
+		return user, nil
+	} else if user == nil {
+		return &models.User{
+			Name:             proposal.NomineeName,
+			TelegramNickname: proposal.NomineeTelegramNickname,
+			Role:             models.UserRoleGuest,
+			BackersID:        backersIDs,
+		}, nil
+	}
 
-func messageForProposalNoQuorumToSeedersGroup(proposal *models.Proposal) tgbotapi.MessageConfig {
-	text := fmt.Sprintf(
-		"Кандидатура %s (@%s) была отклонена по причине отсутствия кворума.",
-		proposal.NomineeName,
-		proposal.NomineeTelegramNickname,
-	)
-	message := tgbotapi.NewMessage(int64(proposal.Poll.ChatID), text)
-	message.BaseChat.ReplyToMessageID = proposal.Poll.PollMessageID
-	return message
+	return nil, nil
 }
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"access_governance_system/configs"
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/db/repositories/mocks"
+	"access_governance_system/internal/services"
+	"access_governance_system/internal/services/mocks"
+
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestUpdateProposalExtended(t *testing.T) {
+	tests := []struct {
+		name          string
+		extendPollErr error
+		wantExtended  bool
+		wantErr       bool
+	}{
+		{name: "poll extended", wantExtended: true},
+		{name: "poll not found", extendPollErr: fmt.Errorf("%w: poll 1", services.ErrNotFound), wantExtended: true},
+		{name: "due date not supported", extendPollErr: fmt.Errorf("%w: poll 1", services.ErrBadRequest), wantExtended: true},
+		{name: "vote api unavailable", extendPollErr: services.ErrUnavailable, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			proposalRepository := mock_repositories.NewMockProposalRepository(ctrl)
+			userRepository := mock_repositories.NewMockUserRepository(ctrl)
+			voteService := mock_services.NewMockVoteService(ctrl)
+
+			proposal := &models.Proposal{ID: 1, Poll: models.Poll{ID: 1}, FinishedAt: time.Now().Add(72 * time.Hour)}
+
+			voteService.EXPECT().ExtendPoll(gomock.Any(), proposal.Poll.ID, proposal.FinishedAt).Return(tt.extendPollErr)
+			if tt.wantExtended {
+				proposalRepository.EXPECT().Extend(proposal, gomock.Any()).Return(proposal, nil)
+			}
+
+			err := updateProposal(
+				context.Background(),
+				&proposalUpdate{proposal: proposal, extended: true},
+				proposalRepository,
+				userRepository,
+				voteService,
+				configs.ProposalStateServiceConfig{},
+				zap.NewNop().Sugar(),
+			)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("updateProposal() error = %v, want error %t", err, tt.wantErr)
+			} else if tt.wantErr && !errors.Is(err, services.ErrUnavailable) {
+				t.Errorf("updateProposal() error = %v, want %v", err, services.ErrUnavailable)
+			}
+		})
+	}
+}
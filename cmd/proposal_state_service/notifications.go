@@ -0,0 +1,289 @@
+package main
+
+import (
+	"fmt"
+
+	"access_governance_system/configs"
+	"access_governance_system/internal"
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/db/repositories"
+	"access_governance_system/internal/outbox"
+	"access_governance_system/internal/policy"
+	tgbot "access_governance_system/internal/tg_bot/extension"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// notificationsFor builds the messages about the update, they are stored to the outbox together with it.
+func notificationsFor(
+	update *proposalUpdate,
+	userRepository repositories.UserRepository,
+	config configs.ProposalStateServiceConfig,
+) ([]*models.OutboxMessage, error) {
+	proposal := update.proposal
+
+	if update.extended {
+		return messagesForProposalExtended(proposal, update.notVotedSeeders), nil
+	}
+
+	nominator, err := userRepository.GetOneByID(proposal.NominatorID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get nominator: %w", err)
+	} else if nominator == nil {
+		return nil, fmt.Errorf("nominator %d not found", proposal.NominatorID)
+	}
+
+	switch proposal.Status {
+	case models.ProposalStatusRejected:
+		return []*models.OutboxMessage{
+			outbox.NewMessage(messageForProposalRejectedToNominator(proposal, update.result, nominator, config.Votes.Anonymous())),
+			outbox.NewMessage(messageForProposalRejectedToSeedersGroup(proposal, update.result)),
+		}, nil
+	case models.ProposalStatusApproved:
+		return append(
+			messagesForProposalApproved(proposal, nominator, config),
+			outbox.NewMessage(messageForProposalApprovedToSeedersGroup(proposal, update.result)),
+		), nil
+	case models.ProposalStatusNoQuorum:
+		return []*models.OutboxMessage{
+			outbox.NewMessage(messageForProposalNoQuorumToNominator(proposal, update.result, nominator)),
+			outbox.NewMessage(messageForProposalNoQuorumToSeedersGroup(proposal, update.result)),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func messageForProposalRejectedToNominator(
+	proposal *models.Proposal,
+	result *models.ProposalResult,
+	nominator *models.User,
+	anonymous bool,
+) tgbotapi.MessageConfig {
+	voting := "Голосование по заявкам проходит"
+	if anonymous {
+		voting += " анонимно"
+	}
+
+	text := fmt.Sprintf(
+		`
+Кандидатура %s (@%s) была отклонена.
+
+Причина: %s.
+%s
+
+_%s в закрытой группе из активных участников сообщества, которые являются носителями ДНК. Повторную заявку на добавление этого человека можно отправить %s._ 
+`,
+		proposal.NomineeName,
+		proposal.NomineeTelegramNickname,
+		rejectionReasonText(result),
+		votingResultText(result),
+		voting,
+		cooldownEndText(proposal),
+	)
+	message := tgbotapi.NewMessage(nominator.TelegramID, text)
+	message.ParseMode = tgbotapi.ModeMarkdown
+	return message
+}
+
+func messageForProposalRejectedToSeedersGroup(proposal *models.Proposal, result *models.ProposalResult) tgbotapi.MessageConfig {
+	text := fmt.Sprintf(
+		"Кандидатура %s (@%s) была отклонена. Причина: %s.\n%s\nПовторная заявка может быть создана %s.",
+		proposal.NomineeName,
+		proposal.NomineeTelegramNickname,
+		rejectionReasonText(result),
+		votingResultText(result),
+		cooldownEndText(proposal),
+	)
+	message := tgbotapi.NewMessage(int64(proposal.Poll.ChatID), text)
+	message.BaseChat.ReplyToMessageID = proposal.Poll.PollMessageID
+	return message
+}
+
+// cooldownEndText tells when the nominee of the rejected proposal can be proposed again,
+// by the same rule the new proposals are checked with.
+func cooldownEndText(proposal *models.Proposal) string {
+	cooldownEnd, ok := policy.CooldownEnd(proposal)
+	if !ok {
+		return "сразу"
+	}
+	return "с " + internal.Format(cooldownEnd)
+}
+
+func rejectionReasonText(result *models.ProposalResult) string {
+	switch result.Reason {
+	case models.DecisionReasonBlocked:
+		return fmt.Sprintf("заявка заблокирована сидерами (блокировок: %d)", result.BlockVotes)
+	case models.DecisionReasonNoQuorum:
+		return fmt.Sprintf(
+			"кворум не состоялся, проголосовали %d из %d необходимых сидеров",
+			result.VotedSeedersCount,
+			result.MinRequiredSeedersCount,
+		)
+	case models.DecisionReasonInsufficientYesVotes:
+		return fmt.Sprintf(
+			"недостаточно голосов «за», получено %d из %d необходимых",
+			result.YesVotes,
+			result.MinRequiredYesVotes,
+		)
+	case models.DecisionReasonOverriddenByNoVotes:
+		return fmt.Sprintf(
+			"голоса «против» не были перекрыты, для этого нужно %d голосов «за», получено %d",
+			result.MinRequiredYesVotesToOverride,
+			result.YesVotes,
+		)
+	default:
+		return "решение принято по итогам голосования"
+	}
+}
+
+func votingResultText(result *models.ProposalResult) string {
+	return fmt.Sprintf(
+		"Итоги голосования: за — %d, против — %d, воздержались — %d. Проголосовали %d из %d сидеров.",
+		result.YesVotes,
+		result.NoVotes,
+		result.AbstainVotes,
+		result.VotedSeedersCount,
+		result.TotalSeedersCount,
+	)
+}
+
+// messagesForProposalApproved leaves the invite link to the outbox, it is created when the message is sent.
+func messagesForProposalApproved(
+	proposal *models.Proposal,
+	nominator *models.User,
+	config configs.ProposalStateServiceConfig,
+) []*models.OutboxMessage {
+	inviteLink := models.OutboxInviteLink{
+		ChatID:                    config.App.MembersChatID,
+		NominatorTelegramNickname: nominator.TelegramNickname,
+		NomineeTelegramNickname:   proposal.NomineeTelegramNickname,
+	}
+	if proposal.NomineeRole == models.NomineeRoleSeeder {
+		inviteLink.ChatID = config.App.SeedersChatID
+	}
+
+	messages := messagesForProposalApprovedToNominator(
+		proposal,
+		nominator,
+		models.OutboxInviteLinkPlaceholder,
+		models.OutboxInviteLinkPlaceholder,
+	)
+
+	return []*models.OutboxMessage{
+		outbox.NewMessage(messages[0]),
+		outbox.NewMessageWithInviteLink(messages[1], inviteLink),
+	}
+}
+
+func messageForProposalApprovedToSeedersGroup(proposal *models.Proposal, result *models.ProposalResult) tgbotapi.MessageConfig {
+	text := fmt.Sprintf(
+		"Кандидатура %s (@%s) была принята.\n%s",
+		proposal.NomineeName,
+		proposal.NomineeTelegramNickname,
+		votingResultText(result),
+	)
+	message := tgbotapi.NewMessage(int64(proposal.Poll.ChatID), text)
+	message.BaseChat.ReplyToMessageID = proposal.Poll.PollMessageID
+	return message
+}
+
+func messagesForProposalApprovedToNominator(
+	proposal *models.Proposal,
+	nominator *models.User,
+	membersChatInviteLink string,
+	seedersChatInviteLink string,
+) []tgbotapi.MessageConfig {
+	return []tgbotapi.MessageConfig{
+		func() tgbotapi.MessageConfig {
+			text := fmt.Sprintf(
+				`
+Кандидатура %s (@%s) была принята.
+
+Перешли ему следующее сообщение:
+`, proposal.NomineeName, proposal.NomineeTelegramNickname,
+			)
+			message := tgbotapi.NewMessage(nominator.TelegramID, text)
+			message.DisableWebPagePreview = true
+			return message
+		}(),
+		func() tgbotapi.MessageConfig {
+			var text string
+			switch proposal.NomineeRole {
+			case models.NomineeRoleMember:
+				text = fmt.Sprintf(
+					`
+Привет! Хочу тебя пригласить вступить в группу Shmit16. Я являюсь участником этого сообщества, и мне удалось получить одобрение на твое вступление. 
+
+Для того, чтобы войти в группу, перейди по [ссылке](%s) и нажми кнопку "Присоединиться".
+
+_Комьюнити Shmit16 выросло из группы IT-предпринимателей, которые собирались на бизнес-вечера по адресу Шмитовский проезд, 16. Спустя 10 лет сообщество насчитывает сотни людей разных специальностей по всему миру. Участие в сообществе бесплатное. Вступая в чат, тебе открывается доступ к мероприятиям и дискуссиям сообщества — фестивали, ретриты, онлайн и офлайн._ 
+`, membersChatInviteLink,
+				)
+			case models.NomineeRoleSeeder:
+				text = fmt.Sprintf(
+					`
+Привет! Тебя повысили до seeder. 
+
+Для того, чтобы войти в группу для сидеров, перейди по [ссылке](%s) и нажми кнопку "Присоединиться".
+`, seedersChatInviteLink,
+				)
+			}
+			message := tgbotapi.NewMessage(nominator.TelegramID, text)
+			message.ParseMode = tgbotapi.ModeMarkdown
+			message.DisableWebPagePreview = true
+			return message
+		}(),
+	}
+}
+
+func messageForProposalNoQuorumToNominator(
+	proposal *models.Proposal,
+	result *models.ProposalResult,
+	nominator *models.User,
+) tgbotapi.MessageConfig {
+	text := fmt.Sprintf(
+		"Кандидатура %s (@%s) была отклонена по причине отсутствия кворума: проголосовали %d из %d необходимых сидеров.",
+		proposal.NomineeName,
+		proposal.NomineeTelegramNickname,
+		result.VotedSeedersCount,
+		result.MinRequiredSeedersCount,
+	)
+	message := tgbotapi.NewMessage(int64(nominator.TelegramID), text)
+	return message
+}
+
+func messageForProposalNoQuorumToSeedersGroup(proposal *models.Proposal, result *models.ProposalResult) tgbotapi.MessageConfig {
+	text := fmt.Sprintf(
+		"Кандидатура %s (@%s) была отклонена по причине отсутствия кворума.\n%s",
+		proposal.NomineeName,
+		proposal.NomineeTelegramNickname,
+		votingResultText(result),
+	)
+	message := tgbotapi.NewMessage(int64(proposal.Poll.ChatID), text)
+	message.BaseChat.ReplyToMessageID = proposal.Poll.PollMessageID
+	return message
+}
+
+func messagesForProposalExtended(proposal *models.Proposal, notVotedSeeders []*models.User) []*models.OutboxMessage {
+	messages := make([]*models.OutboxMessage, 0, len(notVotedSeeders))
+	for _, seeder := range notVotedSeeders {
+		messages = append(messages, outbox.NewMessage(messageForProposalExtendedToSeeder(proposal, seeder)))
+	}
+	return messages
+}
+
+func messageForProposalExtendedToSeeder(proposal *models.Proposal, seeder *models.User) tgbotapi.MessageConfig {
+	text := fmt.Sprintf(
+		"Голосование по кандидатуре %s (@%s) продлено до %s, так как кворум не состоялся. Пожалуйста, проголосуй — без твоего голоса заявка может быть отклонена.",
+		proposal.NomineeName,
+		proposal.NomineeTelegramNickname,
+		internal.Format(proposal.FinishedAt),
+	)
+	message := tgbotapi.NewMessage(seeder.TelegramID, text)
+	message.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonURL("Проголосовать", tgbot.ChatMessageLink(proposal.Poll.ChatID, proposal.Poll.PollMessageID)),
+		),
+	)
+	return message
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"access_governance_system/internal/db/models"
+)
+
+func TestRejectionMessagesCooldownEnd(t *testing.T) {
+	proposal := &models.Proposal{
+		Status:                  models.ProposalStatusRejected,
+		NomineeName:             "Nominee",
+		NomineeTelegramNickname: "nominee",
+		CreatedAt:               time.Date(2026, time.January, 15, 12, 0, 0, 0, time.UTC),
+	}
+	result := &models.ProposalResult{Reason: models.DecisionReasonBlocked, BlockVotes: 1}
+	nominator := &models.User{TelegramID: 10}
+
+	texts := map[string]string{
+		"nominator":     messageForProposalRejectedToNominator(proposal, result, nominator, true).Text,
+		"seeders group": messageForProposalRejectedToSeedersGroup(proposal, result).Text,
+	}
+
+	for name, text := range texts {
+		if !strings.Contains(text, "с 15.04.2026") {
+			t.Errorf("message to the %s does not tell the cooldown end: %s", name, text)
+		}
+	}
+}
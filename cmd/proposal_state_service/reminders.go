@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"access_governance_system/configs"
+	"access_governance_system/internal"
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/db/repositories"
+	"access_governance_system/internal/outbox"
+	tgbot "access_governance_system/internal/tg_bot/extension"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+// queueReminders stores the due reminders of the proposals still being voted on to the outbox,
+// each seeder is reminded once per offset. The votes are the ones the proposals were checked with.
+func queueReminders(
+	updates []*proposalUpdate,
+	seeders []*models.User,
+	proposalReminderRepository repositories.ProposalReminderRepository,
+	config configs.ProposalStateServiceConfig,
+	logger *zap.SugaredLogger,
+) {
+	for _, update := range updates {
+		proposal := update.proposal
+
+		offsetDays, ok := dueReminderOffsetDays(proposal, config.ReminderOffsetsDays, time.Now())
+		if !ok {
+			continue
+		}
+
+		reminders, err := proposalReminderRepository.GetManyByProposalID(proposal.ID)
+		if err != nil {
+			logger.Errorw("failed to get reminders", "error", err, "proposal", proposal)
+			continue
+		}
+
+		for _, seeder := range getNotVotedSeeders(seeders, update.votes) {
+			if reminderWasSent(reminders, seeder, offsetDays, proposal.ExtensionsCount) {
+				continue
+			}
+
+			reminder := &models.ProposalReminder{
+				ProposalID:      proposal.ID,
+				UserID:          seeder.ID,
+				OffsetDays:      offsetDays,
+				ExtensionsCount: proposal.ExtensionsCount,
+			}
+
+			err = proposalReminderRepository.Create(reminder, outbox.NewMessage(messageForReminderToSeeder(proposal, seeder)))
+			if err != nil {
+				logger.Errorw("failed to save reminder", "error", err, "proposal", proposal, "seeder", seeder.TelegramNickname)
+			}
+		}
+	}
+}
+
+// dueReminderOffsetDays returns the smallest offset the proposal deadline is already within,
+// so a late run sends only the most recent reminder instead of all the missed ones.
+func dueReminderOffsetDays(proposal *models.Proposal, offsetsDays []int, now time.Time) (int, bool) {
+	if !proposal.FinishedAt.After(now) {
+		return 0, false
+	}
+
+	offsets := append([]int(nil), offsetsDays...)
+	sort.Ints(offsets)
+
+	for _, offsetDays := range offsets {
+		if offsetDays > 0 && !now.Before(proposal.FinishedAt.AddDate(0, 0, -offsetDays)) {
+			return offsetDays, true
+		}
+	}
+
+	return 0, false
+}
+
+func reminderWasSent(reminders []*models.ProposalReminder, seeder *models.User, offsetDays, extensionsCount int) bool {
+	for _, reminder := range reminders {
+		if reminder.UserID == seeder.ID && reminder.OffsetDays == offsetDays && reminder.ExtensionsCount == extensionsCount {
+			return true
+		}
+	}
+	return false
+}
+
+func messageForReminderToSeeder(proposal *models.Proposal, seeder *models.User) tgbotapi.MessageConfig {
+	text := fmt.Sprintf(
+		"Напоминаем, что голосование по кандидатуре %s (@%s) закончится %s, а ты ещё не проголосовал.",
+		proposal.NomineeName,
+		proposal.NomineeTelegramNickname,
+		internal.Format(proposal.FinishedAt),
+	)
+	message := tgbotapi.NewMessage(seeder.TelegramID, text)
+	message.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonURL("Проголосовать", tgbot.ChatMessageLink(proposal.Poll.ChatID, proposal.Poll.PollMessageID)),
+		),
+	)
+	return message
+}
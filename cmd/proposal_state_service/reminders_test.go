@@ -0,0 +1,94 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"access_governance_system/configs"
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/db/repositories/mocks"
+	"access_governance_system/internal/services"
+
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestDueReminderOffsetDays(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		finishedAt  time.Time
+		offsetsDays []int
+		want        int
+		wantOK      bool
+	}{
+		{name: "before the first offset", finishedAt: now.AddDate(0, 0, 5), offsetsDays: []int{3, 1}},
+		{name: "within the first offset", finishedAt: now.AddDate(0, 0, 2), offsetsDays: []int{3, 1}, want: 3, wantOK: true},
+		{name: "at the first offset", finishedAt: now.AddDate(0, 0, 3), offsetsDays: []int{3, 1}, want: 3, wantOK: true},
+		{name: "missed offsets are skipped", finishedAt: now.Add(time.Hour), offsetsDays: []int{3, 1}, want: 1, wantOK: true},
+		{name: "unsorted offsets", finishedAt: now.Add(time.Hour), offsetsDays: []int{1, 3}, want: 1, wantOK: true},
+		{name: "non-positive offsets are ignored", finishedAt: now.Add(time.Hour), offsetsDays: []int{0, -1}},
+		{name: "no offsets", finishedAt: now.Add(time.Hour)},
+		{name: "voting is over", finishedAt: now, offsetsDays: []int{3, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := dueReminderOffsetDays(&models.Proposal{FinishedAt: tt.finishedAt}, tt.offsetsDays, now)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("dueReminderOffsetDays() = %d, %t, want %d, %t", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestQueueReminders(t *testing.T) {
+	seeders := []*models.User{
+		{ID: 1, TelegramID: 10},
+		{ID: 2, TelegramID: 20},
+		{ID: 3, TelegramID: 30},
+		{ID: 4, TelegramID: 40},
+	}
+
+	proposal := &models.Proposal{ID: 1, ExtensionsCount: 1, FinishedAt: time.Now().Add(12 * time.Hour)}
+	update := &proposalUpdate{
+		proposal: proposal,
+		votes:    []services.Vote{{UserID: 10, Option: services.VoteOptionYes}},
+		waiting:  true,
+	}
+
+	ctrl := gomock.NewController(t)
+	proposalReminderRepository := mock_repositories.NewMockProposalReminderRepository(ctrl)
+
+	proposalReminderRepository.EXPECT().GetManyByProposalID(proposal.ID).Return([]*models.ProposalReminder{
+		// Already reminded at this offset of this extension.
+		{ProposalID: proposal.ID, UserID: 2, OffsetDays: 1, ExtensionsCount: 1},
+		// Reminded at another offset or before the extension, so reminded again.
+		{ProposalID: proposal.ID, UserID: 3, OffsetDays: 3, ExtensionsCount: 1},
+		{ProposalID: proposal.ID, UserID: 4, OffsetDays: 1, ExtensionsCount: 0},
+	}, nil)
+
+	var remindedUserIDs []int
+	proposalReminderRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(reminder *models.ProposalReminder, message *models.OutboxMessage) error {
+			if reminder.OffsetDays != 1 || reminder.ExtensionsCount != 1 {
+				t.Errorf("reminder = %+v, want offset 1 of extension 1", reminder)
+			}
+			remindedUserIDs = append(remindedUserIDs, reminder.UserID)
+			return nil
+		}).
+		Times(2)
+
+	queueReminders(
+		[]*proposalUpdate{update},
+		seeders,
+		proposalReminderRepository,
+		configs.ProposalStateServiceConfig{ReminderOffsetsDays: []int{3, 1}},
+		zap.NewNop().Sugar(),
+	)
+
+	if len(remindedUserIDs) != 2 || remindedUserIDs[0] != 3 || remindedUserIDs[1] != 4 {
+		t.Errorf("reminded users %v, want [3 4]", remindedUserIDs)
+	}
+}
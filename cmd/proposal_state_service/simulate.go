@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"access_governance_system/configs"
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/db/repositories"
+	"access_governance_system/internal/policy"
+	"github.com/go-pg/pg/v10"
+	"go.uber.org/zap"
+)
+
+const (
+	simulateCommandName = "simulate"
+
+	simulationFormatTable = "table"
+	simulationFormatJSON  = "json"
+
+	simulationActionWait     = "wait"
+	simulationActionExtend   = "extend"
+	simulationActionFinalize = "finalize"
+)
+
+type simulationOptions struct {
+	format string
+}
+
+type simulatedProposal struct {
+	ID                      int                   `json:"id"`
+	NomineeName             string                `json:"nominee_name"`
+	NomineeTelegramNickname string                `json:"nominee_telegram_nickname"`
+	NomineeRole             models.NomineeRole    `json:"nominee_role"`
+	FinishedAt              time.Time             `json:"finished_at"`
+	Action                  string                `json:"action"`
+	Status                  models.ProposalStatus `json:"status"`
+	Reason                  models.DecisionReason `json:"reason"`
+	Tally                   policy.Tally          `json:"tally"`
+	Explanation             policy.Explanation    `json:"explanation"`
+	DecidedEarly            bool                  `json:"decided_early"`
+}
+
+func parseSimulationOptions(arguments []string) (simulationOptions, error) {
+	var options simulationOptions
+
+	flags := flag.NewFlagSet(simulateCommandName, flag.ContinueOnError)
+	flags.StringVar(&options.format, "format", simulationFormatTable, "output format, table or json")
+
+	if err := flags.Parse(arguments); err != nil {
+		return options, err
+	}
+
+	if options.format != simulationFormatTable && options.format != simulationFormatJSON {
+		return options, fmt.Errorf("unknown format %q", options.format)
+	}
+
+	return options, nil
+}
+
+// simulate checks the created proposals once the way the scheduled job does
+// and prints what it would do, nothing is saved and no message is sent.
+func simulate(
+	ctx context.Context,
+	output io.Writer,
+	options simulationOptions,
+	database *pg.DB,
+	config configs.ProposalStateServiceConfig,
+	logger *zap.SugaredLogger,
+) error {
+	userRepository := repositories.NewUserRepository(database)
+	proposalRepository := repositories.NewProposalRepository(database)
+	proposalBlockRepository := repositories.NewProposalBlockRepository(database)
+	voteService := newVoteService(database, config)
+
+	seeders, err := userRepository.GetManyByRole(models.UserRoleSeeder)
+	if err != nil {
+		return fmt.Errorf("failed to get seeders: %w", err)
+	}
+
+	proposals, err := proposalRepository.GetManyByStatus(models.ProposalStatusCreated)
+	if err != nil {
+		return fmt.Errorf("failed to get proposals: %w", err)
+	}
+
+	updates, failedCount := checkProposals(ctx, seeders, proposals, voteService, userRepository, proposalBlockRepository, config, logger)
+	if failedCount > 0 {
+		logger.Warnw("some proposals could not be checked and are left out", "count", failedCount)
+	}
+
+	simulatedProposals := make([]simulatedProposal, 0, len(updates))
+	for _, update := range updates {
+		simulatedProposals = append(simulatedProposals, newSimulatedProposal(update))
+	}
+
+	if options.format == simulationFormatJSON {
+		encoder := json.NewEncoder(output)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(simulatedProposals)
+	}
+
+	return writeSimulationTable(output, simulatedProposals)
+}
+
+func newSimulatedProposal(update *proposalUpdate) simulatedProposal {
+	action := simulationActionFinalize
+	if update.waiting {
+		action = simulationActionWait
+	} else if update.extended {
+		action = simulationActionExtend
+	}
+
+	return simulatedProposal{
+		ID:                      update.proposal.ID,
+		NomineeName:             update.proposal.NomineeName,
+		NomineeTelegramNickname: update.proposal.NomineeTelegramNickname,
+		NomineeRole:             update.proposal.NomineeRole,
+		FinishedAt:              update.proposal.FinishedAt,
+		Action:                  action,
+		Status:                  update.decision.Status,
+		Reason:                  update.decision.Reason,
+		Tally:                   update.tally,
+		Explanation:             update.decision.Explanation,
+		DecidedEarly:            update.decision.DecidedEarly,
+	}
+}
+
+// writeSimulationTable prints one row per proposal, for a waiting proposal
+// the status is the one it would get if the voting ended now.
+func writeSimulationTable(output io.Writer, simulatedProposals []simulatedProposal) error {
+	writer := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(writer, "ID\tNOMINEE\tROLE\tFINISHED AT\tACTION\tSTATUS\tREASON\tYES\tNO\tABSTAIN\tBLOCKS\tVOTED\tQUORUM")
+	for _, p := range simulatedProposals {
+		fmt.Fprintf(
+			writer,
+			"%d\t%s (@%s)\t%s\t%s\t%s\t%s\t%s\t%d/%d\t%d\t%d\t%d\t%d/%d\t%d\n",
+			p.ID,
+			p.NomineeName,
+			p.NomineeTelegramNickname,
+			p.NomineeRole,
+			p.FinishedAt.Format(time.RFC3339),
+			p.Action,
+			p.Status,
+			p.Reason,
+			p.Tally.YesVotes,
+			p.Explanation.MinRequiredYesVotes,
+			p.Tally.NoVotes,
+			p.Tally.AbstainVotes,
+			p.Tally.BlockVotes,
+			p.Tally.VotedSeedersCount,
+			p.Tally.TotalSeedersCount,
+			p.Explanation.MinRequiredSeedersCount,
+		)
+	}
+
+	return writer.Flush()
+}
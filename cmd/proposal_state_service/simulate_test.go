@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/policy"
+)
+
+func TestWriteSimulationTable(t *testing.T) {
+	proposal := &models.Proposal{
+		ID:                      1,
+		NomineeName:             "Nominee",
+		NomineeTelegramNickname: "nominee",
+		NomineeRole:             models.NomineeRoleMember,
+		FinishedAt:              time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC),
+	}
+	tally := policy.Tally{YesVotes: 3, NoVotes: 1, AbstainVotes: 1, VotedSeedersCount: 5, TotalSeedersCount: 10}
+
+	simulatedProposals := []simulatedProposal{
+		newSimulatedProposal(&proposalUpdate{
+			proposal: proposal,
+			tally:    tally,
+			decision: policy.NewDefaultPolicy(whatIfTestRules).Decide(tally),
+			waiting:  true,
+		}),
+	}
+
+	var output bytes.Buffer
+	if err := writeSimulationTable(&output, simulatedProposals); err != nil {
+		t.Fatalf("writeSimulationTable() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want a header and a row:\n%s", len(lines), output.String())
+	}
+
+	wantHeader := []string{
+		"ID", "NOMINEE", "ROLE", "FINISHED", "AT", "ACTION", "STATUS", "REASON", "YES", "NO", "ABSTAIN", "BLOCKS", "VOTED", "QUORUM",
+	}
+	if got := strings.Fields(lines[0]); strings.Join(got, " ") != strings.Join(wantHeader, " ") {
+		t.Errorf("header = %q, want %q", strings.Join(got, " "), strings.Join(wantHeader, " "))
+	}
+
+	wantRow := []string{
+		"1", "Nominee", "(@nominee)", "member", "2026-03-01T12:00:00Z", "wait", "rejected", "overridden_by_no_votes", "3/2", "1", "1", "0", "5/10", "5",
+	}
+	if got := strings.Fields(lines[1]); strings.Join(got, " ") != strings.Join(wantRow, " ") {
+		t.Errorf("row = %q, want %q", strings.Join(got, " "), strings.Join(wantRow, " "))
+	}
+}
+
+func TestNewSimulatedProposalAction(t *testing.T) {
+	tests := []struct {
+		name   string
+		update proposalUpdate
+		want   string
+	}{
+		{name: "waiting", update: proposalUpdate{waiting: true}, want: simulationActionWait},
+		{name: "extended", update: proposalUpdate{extended: true}, want: simulationActionExtend},
+		{name: "finalized", update: proposalUpdate{}, want: simulationActionFinalize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.update.proposal = &models.Proposal{ID: 1}
+			if got := newSimulatedProposal(&tt.update).Action; got != tt.want {
+				t.Errorf("Action = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,376 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"access_governance_system/configs"
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/db/repositories"
+	"access_governance_system/internal/policy"
+	"access_governance_system/internal/services"
+	"github.com/go-pg/pg/v10"
+	"go.uber.org/zap"
+)
+
+const (
+	whatIfCommandName = "what-if"
+
+	whatIfFormatCSV      = "csv"
+	whatIfFormatMarkdown = "markdown"
+
+	tallySourceResult      = "result"
+	tallySourceVotes       = "votes"
+	tallySourceUnavailable = "unavailable"
+)
+
+type whatIfOptions struct {
+	format string
+	role   string
+	all    bool
+
+	candidate models.VotingRules
+	// changed holds the names of the candidate flags that were set,
+	// the other parameters are kept as each proposal was decided with.
+	changed map[string]bool
+}
+
+type replayedProposal struct {
+	proposal        *models.Proposal
+	tally           policy.Tally
+	tallySource     string
+	baselineStatus  models.ProposalStatus // under the rules the proposal was decided with, on the same tally
+	candidateStatus models.ProposalStatus
+	candidateReason models.DecisionReason
+	err             error // why the tally could not be loaded, the proposal is then not replayed
+}
+
+// flipped compares the candidate decision with the baseline one rather than with the stored status,
+// which an approximate tally or a replayed extension can differ from with no candidate parameter set.
+func (p replayedProposal) flipped() bool {
+	return p.err == nil && p.baselineStatus != p.candidateStatus
+}
+
+// printed tells whether the proposal is in the output, the ones which could not be replayed always are,
+// so the report never looks complete when it is not.
+func (p replayedProposal) printed(options whatIfOptions) bool {
+	return options.all || p.flipped() || p.err != nil
+}
+
+func parseWhatIfOptions(arguments []string) (whatIfOptions, error) {
+	options := whatIfOptions{changed: make(map[string]bool)}
+
+	flags := flag.NewFlagSet(whatIfCommandName, flag.ContinueOnError)
+	flags.StringVar(&options.format, "format", whatIfFormatMarkdown, "output format, csv or markdown")
+	flags.StringVar(&options.role, "role", "", "replay only proposals for the nominee role, member or seeder")
+	flags.BoolVar(&options.all, "all", false, "print all the replayed proposals, not only the flipped ones")
+	flags.Float64Var(&options.candidate.Quorum, "quorum", 0, "candidate QUORUM")
+	flags.Float64Var(&options.candidate.MaxRequiredSeedersCount, "max-required-seeders-count", 0, "candidate MAX_REQUIRED_SEEDERS_COUNT")
+	flags.Float64Var(&options.candidate.MinYesVotesPercentage, "min-yes-votes-percentage", 0, "candidate MIN_YES_VOTES_PERCENTAGE")
+	flags.Float64Var(&options.candidate.MinRequiredYesVotes, "min-required-yes-votes", 0, "candidate MIN_REQUIRED_YES_VOTES")
+	flags.Float64Var(&options.candidate.YesVotesToOvercomeNo, "yes-votes-to-overcome-no", 0, "candidate YES_VOTES_TO_OVERCOME_NO")
+	flags.IntVar(&options.candidate.BlocksToReject, "blocks-to-reject", 0, "candidate BLOCKS_TO_REJECT")
+
+	if err := flags.Parse(arguments); err != nil {
+		return options, err
+	}
+
+	flags.Visit(func(f *flag.Flag) {
+		options.changed[f.Name] = true
+	})
+
+	if options.format != whatIfFormatCSV && options.format != whatIfFormatMarkdown {
+		return options, fmt.Errorf("unknown format %q", options.format)
+	}
+
+	if options.role != "" && options.role != models.NomineeRoleMember.String() && options.role != models.NomineeRoleSeeder.String() {
+		return options, fmt.Errorf("unknown role %q", options.role)
+	}
+
+	return options, nil
+}
+
+// candidateRules replaces the parameters set on the command line in the rules a proposal was decided with.
+func (o whatIfOptions) candidateRules(rules models.VotingRules) models.VotingRules {
+	if o.changed["quorum"] {
+		rules.Quorum = o.candidate.Quorum
+	}
+	if o.changed["max-required-seeders-count"] {
+		rules.MaxRequiredSeedersCount = o.candidate.MaxRequiredSeedersCount
+	}
+	if o.changed["min-yes-votes-percentage"] {
+		rules.MinYesVotesPercentage = o.candidate.MinYesVotesPercentage
+	}
+	if o.changed["min-required-yes-votes"] {
+		rules.MinRequiredYesVotes = o.candidate.MinRequiredYesVotes
+	}
+	if o.changed["yes-votes-to-overcome-no"] {
+		rules.YesVotesToOvercomeNo = o.candidate.YesVotesToOvercomeNo
+	}
+	if o.changed["blocks-to-reject"] {
+		rules.BlocksToReject = o.candidate.BlocksToReject
+	}
+	return rules
+}
+
+// whatIf replays every decided proposal under the candidate parameters and prints the outcomes that would flip.
+// The tallies are taken from the stored results, proposals decided before the results were stored
+// are counted again from the vote API with the current seeders, so their tallies are approximate.
+// Voting extensions are not replayed, a missed quorum is reported as is.
+// A proposal whose tally cannot be loaded is reported as unavailable instead of being left out.
+// Both the baseline and the candidate decisions are made on the same tally, so only the parameters make them differ.
+func whatIf(
+	ctx context.Context,
+	output io.Writer,
+	options whatIfOptions,
+	database *pg.DB,
+	config configs.ProposalStateServiceConfig,
+	logger *zap.SugaredLogger,
+) error {
+	userRepository := repositories.NewUserRepository(database)
+	proposalRepository := repositories.NewProposalRepository(database)
+	proposalResultRepository := repositories.NewProposalResultRepository(database)
+	proposalBlockRepository := repositories.NewProposalBlockRepository(database)
+	voteService := newVoteService(database, config)
+
+	seeders, err := userRepository.GetManyByRole(models.UserRoleSeeder)
+	if err != nil {
+		return fmt.Errorf("failed to get seeders: %w", err)
+	}
+
+	proposals, err := proposalRepository.GetManyByStatus(
+		models.ProposalStatusApproved,
+		models.ProposalStatusRejected,
+		models.ProposalStatusNoQuorum,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get proposals: %w", err)
+	}
+
+	sort.Slice(proposals, func(i, j int) bool {
+		return proposals[i].ID < proposals[j].ID
+	})
+
+	replayedProposals := make([]replayedProposal, 0, len(proposals))
+
+	for _, proposal := range proposals {
+		if options.role != "" && proposal.NomineeRole.String() != options.role {
+			continue
+		}
+
+		replayed := replayedProposal{proposal: proposal}
+
+		replayed.tally, replayed.tallySource, replayed.err = replayedTally(
+			ctx,
+			proposal,
+			seeders,
+			voteService,
+			userRepository,
+			proposalResultRepository,
+			proposalBlockRepository,
+			logger,
+		)
+		if replayed.err != nil {
+			logger.Errorw("failed to replay proposal", "error", replayed.err, "proposal", proposal)
+			replayed.tallySource = tallySourceUnavailable
+			replayedProposals = append(replayedProposals, replayed)
+			continue
+		}
+
+		votingRules := policy.VotingRulesFor(proposal, config.App, config.Policies)
+		replayed.baselineStatus = policy.NewDefaultPolicy(votingRules).Decide(replayed.tally).Status
+
+		decision := policy.NewDefaultPolicy(options.candidateRules(votingRules)).Decide(replayed.tally)
+		replayed.candidateStatus = decision.Status
+		replayed.candidateReason = decision.Reason
+
+		replayedProposals = append(replayedProposals, replayed)
+	}
+
+	if options.format == whatIfFormatCSV {
+		return writeWhatIfCSV(output, options, replayedProposals)
+	}
+
+	return writeWhatIfMarkdown(output, options, replayedProposals)
+}
+
+// replayedTally returns the stored tally of the proposal, or counts it again when no result is stored.
+func replayedTally(
+	ctx context.Context,
+	proposal *models.Proposal,
+	seeders []*models.User,
+	voteService services.VoteService,
+	userRepository repositories.UserRepository,
+	proposalResultRepository repositories.ProposalResultRepository,
+	proposalBlockRepository repositories.ProposalBlockRepository,
+	logger *zap.SugaredLogger,
+) (policy.Tally, string, error) {
+	result, err := proposalResultRepository.GetOneByProposalID(proposal.ID)
+	if err != nil {
+		return policy.Tally{}, "", fmt.Errorf("failed to get proposal result: %w", err)
+	}
+
+	if result != nil {
+		return policy.Tally{
+			YesVotes:          result.YesVotes,
+			NoVotes:           result.NoVotes,
+			AbstainVotes:      result.AbstainVotes,
+			BlockVotes:        result.BlockVotes,
+			VotedSeedersCount: result.VotedSeedersCount,
+			TotalSeedersCount: result.TotalSeedersCount,
+		}, tallySourceResult, nil
+	}
+
+	votes, err := voteService.GetVotes(ctx, proposal.Poll.ID)
+	if err != nil {
+		return policy.Tally{}, "", fmt.Errorf("failed to get votes: %w", err)
+	}
+
+	blocks, err := proposalBlockRepository.GetManyByProposalID(proposal.ID)
+	if err != nil {
+		return policy.Tally{}, "", fmt.Errorf("failed to get blocks: %w", err)
+	}
+
+	tally := policy.CountVotes(votes)
+	tally.BlockVotes = len(blocks)
+	tally.VotedSeedersCount = countVotedSeeders(votes, userRepository, logger)
+	tally.TotalSeedersCount = totalSeedersCountFor(proposal, seeders)
+	return tally, tallySourceVotes, nil
+}
+
+func whatIfHeader() []string {
+	return []string{
+		"id", "nominee", "role", "finished_at", "tally_source",
+		"yes", "no", "abstain", "blocks", "voted", "total",
+		"status", "baseline_status", "candidate_status", "candidate_reason", "flipped", "error",
+	}
+}
+
+func whatIfRow(p replayedProposal) []string {
+	if p.err != nil {
+		return []string{
+			strconv.Itoa(p.proposal.ID),
+			fmt.Sprintf("%s (@%s)", p.proposal.NomineeName, p.proposal.NomineeTelegramNickname),
+			p.proposal.NomineeRole.String(),
+			p.proposal.FinishedAt.Format("2006-01-02"),
+			p.tallySource,
+			"", "", "", "", "", "",
+			p.proposal.Status.String(),
+			"", "", "", "",
+			p.err.Error(),
+		}
+	}
+
+	return []string{
+		strconv.Itoa(p.proposal.ID),
+		fmt.Sprintf("%s (@%s)", p.proposal.NomineeName, p.proposal.NomineeTelegramNickname),
+		p.proposal.NomineeRole.String(),
+		p.proposal.FinishedAt.Format("2006-01-02"),
+		p.tallySource,
+		strconv.Itoa(p.tally.YesVotes),
+		strconv.Itoa(p.tally.NoVotes),
+		strconv.Itoa(p.tally.AbstainVotes),
+		strconv.Itoa(p.tally.BlockVotes),
+		strconv.Itoa(p.tally.VotedSeedersCount),
+		strconv.Itoa(p.tally.TotalSeedersCount),
+		p.proposal.Status.String(),
+		p.baselineStatus.String(),
+		p.candidateStatus.String(),
+		p.candidateReason.String(),
+		strconv.FormatBool(p.flipped()),
+		"",
+	}
+}
+
+func writeWhatIfCSV(output io.Writer, options whatIfOptions, replayedProposals []replayedProposal) error {
+	writer := csv.NewWriter(output)
+
+	if err := writer.Write(whatIfHeader()); err != nil {
+		return err
+	}
+
+	for _, p := range replayedProposals {
+		if !p.printed(options) {
+			continue
+		}
+		if err := writer.Write(whatIfRow(p)); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeWhatIfMarkdown(output io.Writer, options whatIfOptions, replayedProposals []replayedProposal) error {
+	flippedCount, unavailableCount := 0, 0
+	for _, p := range replayedProposals {
+		if p.err != nil {
+			unavailableCount++
+		} else if p.flipped() {
+			flippedCount++
+		}
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString("### What if\n\n")
+	builder.WriteString(fmt.Sprintf("Candidate parameters: %s\n\n", options.changedParameters()))
+	builder.WriteString(fmt.Sprintf("%d of %d decided proposals would flip.\n\n", flippedCount, len(replayedProposals)-unavailableCount))
+	if unavailableCount > 0 {
+		builder.WriteString(fmt.Sprintf(
+			"%d more decided proposals could not be replayed, they are listed with the `%s` tally source.\n\n",
+			unavailableCount,
+			tallySourceUnavailable,
+		))
+	}
+
+	header := whatIfHeader()
+	builder.WriteString("| " + strings.Join(header, " | ") + " |\n")
+	builder.WriteString("|" + strings.Repeat("---|", len(header)) + "\n")
+
+	for _, p := range replayedProposals {
+		if !p.printed(options) {
+			continue
+		}
+		row := whatIfRow(p)
+		for i, cell := range row {
+			row[i] = strings.ReplaceAll(cell, "|", "\\|")
+		}
+		builder.WriteString("| " + strings.Join(row, " | ") + " |\n")
+	}
+
+	_, err := io.WriteString(output, builder.String())
+	return err
+}
+
+func (o whatIfOptions) changedParameters() string {
+	var parameters []string
+	for _, parameter := range []struct {
+		name  string
+		value string
+	}{
+		{"quorum", fmt.Sprint(o.candidate.Quorum)},
+		{"max-required-seeders-count", fmt.Sprint(o.candidate.MaxRequiredSeedersCount)},
+		{"min-yes-votes-percentage", fmt.Sprint(o.candidate.MinYesVotesPercentage)},
+		{"min-required-yes-votes", fmt.Sprint(o.candidate.MinRequiredYesVotes)},
+		{"yes-votes-to-overcome-no", fmt.Sprint(o.candidate.YesVotesToOvercomeNo)},
+		{"blocks-to-reject", fmt.Sprint(o.candidate.BlocksToReject)},
+		{"role", o.role},
+	} {
+		if o.changed[parameter.name] {
+			parameters = append(parameters, fmt.Sprintf("`-%s=%s`", parameter.name, parameter.value))
+		}
+	}
+
+	if len(parameters) == 0 {
+		return "none"
+	}
+	return strings.Join(parameters, ", ")
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/policy"
+)
+
+var whatIfTestRules = models.VotingRules{
+	Quorum:                  0.5,
+	MaxRequiredSeedersCount: 10,
+	MinYesVotesPercentage:   0.5,
+	MinRequiredYesVotes:     2,
+	YesVotesToOvercomeNo:    0.5,
+}
+
+// replay decides the tally under the rules and under the rules changed by the options, like whatIf does.
+func replay(id int, status models.ProposalStatus, tally policy.Tally, options whatIfOptions) replayedProposal {
+	baseline := policy.NewDefaultPolicy(whatIfTestRules).Decide(tally)
+	candidate := policy.NewDefaultPolicy(options.candidateRules(whatIfTestRules)).Decide(tally)
+
+	return replayedProposal{
+		proposal: &models.Proposal{
+			ID:                      id,
+			NomineeName:             "Nominee",
+			NomineeTelegramNickname: "nominee",
+			NomineeRole:             models.NomineeRoleMember,
+			Status:                  status,
+			FinishedAt:              time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC),
+		},
+		tally:           tally,
+		tallySource:     tallySourceResult,
+		baselineStatus:  baseline.Status,
+		candidateStatus: candidate.Status,
+		candidateReason: candidate.Reason,
+	}
+}
+
+func TestReplayedProposalFlipped(t *testing.T) {
+	quorum := whatIfOptions{candidate: models.VotingRules{Quorum: 0.6}, changed: map[string]bool{"quorum": true}}
+
+	tests := []struct {
+		name    string
+		status  models.ProposalStatus
+		tally   policy.Tally
+		options whatIfOptions
+		want    bool
+	}{
+		{
+			name:    "no candidate parameter",
+			status:  models.ProposalStatusApproved,
+			tally:   policy.Tally{YesVotes: 5, VotedSeedersCount: 5, TotalSeedersCount: 10},
+			options: whatIfOptions{changed: map[string]bool{}},
+		},
+		{
+			name:    "higher quorum is missed",
+			status:  models.ProposalStatusApproved,
+			tally:   policy.Tally{YesVotes: 5, VotedSeedersCount: 5, TotalSeedersCount: 10},
+			options: quorum,
+			want:    true,
+		},
+		{
+			name:    "higher quorum is reached",
+			status:  models.ProposalStatusApproved,
+			tally:   policy.Tally{YesVotes: 6, VotedSeedersCount: 6, TotalSeedersCount: 10},
+			options: quorum,
+		},
+		{
+			name:    "stored status differs from the recounted baseline",
+			status:  models.ProposalStatusApproved,
+			tally:   policy.Tally{YesVotes: 4, VotedSeedersCount: 4, TotalSeedersCount: 10},
+			options: whatIfOptions{changed: map[string]bool{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replay(1, tt.status, tt.tally, tt.options).flipped(); got != tt.want {
+				t.Errorf("flipped() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+// whatIfTestReport raises the quorum, so the first proposal flips, the second does not and the third is not replayed.
+func whatIfTestReport() (whatIfOptions, []replayedProposal) {
+	options := whatIfOptions{candidate: models.VotingRules{Quorum: 0.6}, changed: map[string]bool{"quorum": true}}
+
+	unavailable := replay(3, models.ProposalStatusRejected, policy.Tally{}, options)
+	unavailable.tallySource = tallySourceUnavailable
+	unavailable.err = errors.New("vote api: unavailable")
+
+	replayedProposals := []replayedProposal{
+		replay(1, models.ProposalStatusApproved, policy.Tally{YesVotes: 5, VotedSeedersCount: 5, TotalSeedersCount: 10}, options),
+		replay(2, models.ProposalStatusApproved, policy.Tally{YesVotes: 6, VotedSeedersCount: 6, TotalSeedersCount: 10}, options),
+		unavailable,
+	}
+
+	return options, replayedProposals
+}
+
+func TestWriteWhatIfCSV(t *testing.T) {
+	options, replayedProposals := whatIfTestReport()
+
+	var output bytes.Buffer
+	if err := writeWhatIfCSV(&output, options, replayedProposals); err != nil {
+		t.Fatalf("writeWhatIfCSV() error = %v", err)
+	}
+
+	records, err := csv.NewReader(&output).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read CSV: %v", err)
+	}
+
+	want := [][]string{
+		whatIfHeader(),
+		{"1", "Nominee (@nominee)", "member", "2026-03-01", "result", "5", "0", "0", "0", "5", "10", "approved", "approved", "no_quorum", "no_quorum", "true", ""},
+		{"3", "Nominee (@nominee)", "member", "2026-03-01", "unavailable", "", "", "", "", "", "", "rejected", "", "", "", "", "vote api: unavailable"},
+	}
+
+	if len(records) != len(want) {
+		t.Fatalf("got %d records, want %d: %v", len(records), len(want), records)
+	}
+	for i := range want {
+		if strings.Join(records[i], ",") != strings.Join(want[i], ",") {
+			t.Errorf("record %d = %v, want %v", i, records[i], want[i])
+		}
+	}
+}
+
+func TestWriteWhatIfMarkdown(t *testing.T) {
+	options, replayedProposals := whatIfTestReport()
+
+	var output bytes.Buffer
+	if err := writeWhatIfMarkdown(&output, options, replayedProposals); err != nil {
+		t.Fatalf("writeWhatIfMarkdown() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"Candidate parameters: `-quorum=0.6`",
+		"1 of 2 decided proposals would flip.",
+		"1 more decided proposals could not be replayed",
+		"| 1 | Nominee (@nominee) |",
+		"| 3 | Nominee (@nominee) |",
+	} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("report does not contain %q:\n%s", want, output.String())
+		}
+	}
+
+	if strings.Contains(output.String(), "| 2 |") {
+		t.Errorf("report contains the proposal which does not flip:\n%s", output.String())
+	}
+}
@@ -1,9 +1,12 @@
 package configs
 
 type App struct {
-	Environment        string   `env:"ENVIRONMENT,notEmpty"`
-	VotingDurationDays int      `env:"VOTING_DURATION_DAYS" envDefault:"7"`
-	InitialSeeders     []string `env:"INITIAL_SEEDERS" envSeparator:","`
-	MembersChatID      int64    `env:"MEMBERS_CHAT_ID"`
-	SeedersChatID      int64    `env:"SEEDERS_CHAT_ID"`
+	Environment         string   `env:"ENVIRONMENT,notEmpty"`
+	VotingDurationDays  int      `env:"VOTING_DURATION_DAYS" envDefault:"7"`
+	VotingExtensionDays int      `env:"VOTING_EXTENSION_DAYS" envDefault:"3"` // 0 disables extensions
+	MaxVotingExtensions int      `env:"MAX_VOTING_EXTENSIONS" envDefault:"1"`
+	InitialSeeders      []string `env:"INITIAL_SEEDERS" envSeparator:","`
+	MembersChatID       int64    `env:"MEMBERS_CHAT_ID"`
+	SeedersChatID       int64    `env:"SEEDERS_CHAT_ID"`
+	Timezone            Location `env:"TIMEZONE" envDefault:"UTC"` // the community time zone voting deadlines are set in
 }
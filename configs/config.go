@@ -3,8 +3,6 @@ package configs
 import (
 	"fmt"
 	"os"
-
-	"github.com/caarlos0/env/v6"
 )
 
 type AccessGovernanceBotConfig struct {
@@ -13,6 +11,8 @@ type AccessGovernanceBotConfig struct {
 	AccessGovernanceBot Bot
 	VoteBot             Bot
 	VoteAPI             VoteAPI
+	Votes               Votes
+	Policies            Policies
 
 	DiscordInviteLink string `env:"DISCORD_INVITE_LINK"`
 }
@@ -20,13 +20,26 @@ type AccessGovernanceBotConfig struct {
 func LoadAccessGovernanceBotConfig() (AccessGovernanceBotConfig, error) {
 	var config AccessGovernanceBotConfig
 
-	if err := env.Parse(&config); err != nil {
+	if err := parseEnv(&config); err != nil {
 		return AccessGovernanceBotConfig{}, fmt.Errorf("failed to parse config: %w", err)
 	}
 
+	if err := parseSeederPolicy(&config.Policies); err != nil {
+		return AccessGovernanceBotConfig{}, fmt.Errorf("failed to parse seeder policy: %w", err)
+	}
+
+	if err := config.Votes.validate(); err != nil {
+		return AccessGovernanceBotConfig{}, fmt.Errorf("failed to parse votes config: %w", err)
+	}
+
 	config.AccessGovernanceBot.Token = os.Getenv("TELEGRAM_ACCESS_GOVERNANCE_BOT_TOKEN")
 	config.VoteBot.Token = os.Getenv("TELEGRAM_VOTE_BOT_TOKEN")
 
+	// The native polls are posted by the access governance bot, so it replies to them too.
+	if config.Votes.Source == VotesSourceTelegram {
+		config.VoteBot = config.AccessGovernanceBot
+	}
+
 	return config, nil
 }
 
@@ -36,21 +49,33 @@ type ProposalStateServiceConfig struct {
 	Logger              Logger
 	AccessGovernanceBot Bot
 	VoteAPI             VoteAPI
+	Votes               Votes
+	Policies            Policies
+	Outbox              Outbox
 
-	Quorum                  float64 `env:"QUORUM"`                     // 30% initial parameter for quorum
-	MaxRequiredSeedersCount float64 `env:"MAX_REQUIRED_SEEDERS_COUNT"` // But not more than 10 votes
-	MinYesVotesPercentage   float64 `env:"MIN_YES_VOTES_PERCENTAGE"`   // Minimum 10% of votes should be "Yes"
-	MinRequiredYesVotes     float64 `env:"MIN_REQUIRED_YES_VOTES"`     // But not less than 3
-	YesVotesToOvercomeNo    float64 `env:"YES_VOTES_TO_OVERCOME_NO"`   // 50% "yes" votes to overcome one "No vote"
+	ReminderOffsetsDays []int  `env:"REMINDER_OFFSETS_DAYS" envSeparator:"," envDefault:"3,1"`
+	Schedule            string `env:"SCHEDULE" envDefault:"*/5 * * * *"`
 }
 
 func LoadProposalStateServiceConfig() (ProposalStateServiceConfig, error) {
 	var config ProposalStateServiceConfig
 
-	if err := env.Parse(&config); err != nil {
+	if err := parseEnv(&config); err != nil {
 		return ProposalStateServiceConfig{}, fmt.Errorf("failed to parse config: %w", err)
 	}
 
+	if err := parseSeederPolicy(&config.Policies); err != nil {
+		return ProposalStateServiceConfig{}, fmt.Errorf("failed to parse seeder policy: %w", err)
+	}
+
+	if err := config.Votes.validate(); err != nil {
+		return ProposalStateServiceConfig{}, fmt.Errorf("failed to parse votes config: %w", err)
+	}
+
+	if err := config.Votes.validateWebhook(); err != nil {
+		return ProposalStateServiceConfig{}, fmt.Errorf("failed to parse votes config: %w", err)
+	}
+
 	config.AccessGovernanceBot.Token = os.Getenv("TELEGRAM_ACCESS_GOVERNANCE_BOT_TOKEN")
 	config.Logger.AppName = "proposal-state-service"
 
@@ -68,7 +93,7 @@ type TelegramAuthrozationBotConfig struct {
 func LoadTelegramAuthrozationBotConfig() (TelegramAuthrozationBotConfig, error) {
 	var config TelegramAuthrozationBotConfig
 
-	if err := env.Parse(&config); err != nil {
+	if err := parseEnv(&config); err != nil {
 		return TelegramAuthrozationBotConfig{}, fmt.Errorf("failed to parse config: %w", err)
 	}
 
@@ -87,7 +112,7 @@ type DiscordAuthrozationBotConfig struct {
 func LoadDiscordAuthrozationBotConfig() (DiscordAuthrozationBotConfig, error) {
 	var config DiscordAuthrozationBotConfig
 
-	if err := env.Parse(&config); err != nil {
+	if err := parseEnv(&config); err != nil {
 		return DiscordAuthrozationBotConfig{}, fmt.Errorf("failed to parse config: %w", err)
 	}
 
@@ -0,0 +1,29 @@
+package configs
+
+import (
+	"os"
+	"strings"
+
+	"github.com/caarlos0/env/v6"
+)
+
+// parseEnv parses the config like env.Parse, but a variable set to an empty value falls back to its default
+// as if it were unset, the images pass every build argument through even when it is not defined.
+func parseEnv(config interface{}, opts ...env.Options) error {
+	environment := make(map[string]string)
+	for _, variable := range os.Environ() {
+		key, value, _ := strings.Cut(variable, "=")
+		if value != "" {
+			environment[key] = value
+		}
+	}
+
+	if len(opts) == 0 {
+		opts = []env.Options{{}}
+	}
+	for i := range opts {
+		opts[i].Environment = environment
+	}
+
+	return env.Parse(config, opts...)
+}
@@ -0,0 +1,70 @@
+package configs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadProposalStateServiceConfigWithEmptyVariables(t *testing.T) {
+	t.Setenv("ENVIRONMENT", "test")
+	t.Setenv("DB_URL", "postgres://localhost/test")
+	t.Setenv("TIMEZONE", "")
+	t.Setenv("SCHEDULE", "")
+	t.Setenv("VOTING_EXTENSION_DAYS", "")
+	t.Setenv("REMINDER_OFFSETS_DAYS", "")
+	t.Setenv("OUTBOX_DISPATCH_INTERVAL", "")
+	t.Setenv("VOTES_SOURCE", "")
+
+	config, err := LoadProposalStateServiceConfig()
+	if err != nil {
+		t.Fatalf("LoadProposalStateServiceConfig() error = %v", err)
+	}
+
+	if config.App.Timezone.Location != time.UTC {
+		t.Errorf("Timezone = %v, want UTC", config.App.Timezone.Location)
+	}
+	if config.App.VotingExtensionDays != 3 {
+		t.Errorf("VotingExtensionDays = %d, want the default", config.App.VotingExtensionDays)
+	}
+	if len(config.ReminderOffsetsDays) != 2 || config.ReminderOffsetsDays[0] != 3 || config.ReminderOffsetsDays[1] != 1 {
+		t.Errorf("ReminderOffsetsDays = %v, want the default", config.ReminderOffsetsDays)
+	}
+	if config.Outbox.DispatchInterval != 30*time.Second {
+		t.Errorf("DispatchInterval = %s, want the default", config.Outbox.DispatchInterval)
+	}
+	if config.Schedule != "*/5 * * * *" {
+		t.Errorf("Schedule = %q, want the default", config.Schedule)
+	}
+	if config.Votes.Source != VotesSourceAPI {
+		t.Errorf("Votes.Source = %q, want the default", config.Votes.Source)
+	}
+}
+
+func TestLoadConfigWithWebhookVotesSource(t *testing.T) {
+	t.Setenv("ENVIRONMENT", "test")
+	t.Setenv("DB_URL", "postgres://localhost/test")
+	t.Setenv("VOTES_SOURCE", VotesSourceWebhook)
+	t.Setenv("VOTES_WEBHOOK_SECRET", "")
+
+	// Only the proposal state service serves the vote webhook.
+	if _, err := LoadAccessGovernanceBotConfig(); err != nil {
+		t.Errorf("LoadAccessGovernanceBotConfig() error = %v", err)
+	}
+	if _, err := LoadProposalStateServiceConfig(); err == nil {
+		t.Error("LoadProposalStateServiceConfig() accepts the webhook votes source without the secret")
+	}
+}
+
+func TestParseSeederPolicyWithEmptyVariables(t *testing.T) {
+	t.Setenv("SEEDER_QUORUM", "")
+	t.Setenv("SEEDER_BLOCKS_TO_REJECT", "2")
+
+	policies := Policies{Seeder: Policy{Quorum: 0.3}}
+	if err := parseSeederPolicy(&policies); err != nil {
+		t.Fatalf("parseSeederPolicy() error = %v", err)
+	}
+
+	if policies.Seeder.Quorum != 0.3 || policies.Seeder.BlocksToReject != 2 {
+		t.Errorf("Seeder = %+v, want the member quorum and 2 blocks to reject", policies.Seeder)
+	}
+}
@@ -0,0 +1,24 @@
+package configs
+
+import (
+	"fmt"
+	"time"
+
+	// The images do not always ship the IANA time zone database.
+	_ "time/tzdata"
+)
+
+// Location is a time zone parsed from its IANA name, for example Europe/Moscow.
+type Location struct {
+	*time.Location
+}
+
+func (l *Location) UnmarshalText(text []byte) error {
+	location, err := time.LoadLocation(string(text))
+	if err != nil {
+		return fmt.Errorf("failed to load location %q: %w", text, err)
+	}
+
+	l.Location = location
+	return nil
+}
@@ -0,0 +1,10 @@
+package configs
+
+import "time"
+
+type Outbox struct {
+	DispatchInterval time.Duration `env:"OUTBOX_DISPATCH_INTERVAL" envDefault:"30s"`
+	MaxAttempts      int           `env:"OUTBOX_MAX_ATTEMPTS" envDefault:"10"` // after that a message is dead-lettered
+	RetryDelay       time.Duration `env:"OUTBOX_RETRY_DELAY" envDefault:"1m"`  // doubled after every failed attempt
+	MaxRetryDelay    time.Duration `env:"OUTBOX_MAX_RETRY_DELAY" envDefault:"1h"`
+}
@@ -0,0 +1,24 @@
+package configs
+
+import "github.com/caarlos0/env/v6"
+
+type Policy struct {
+	Quorum                  float64 `env:"QUORUM"`                     // 30% initial parameter for quorum
+	MaxRequiredSeedersCount float64 `env:"MAX_REQUIRED_SEEDERS_COUNT"` // But not more than 10 votes
+	MinYesVotesPercentage   float64 `env:"MIN_YES_VOTES_PERCENTAGE"`   // Minimum 10% of votes should be "Yes"
+	MinRequiredYesVotes     float64 `env:"MIN_REQUIRED_YES_VOTES"`     // But not less than 3
+	YesVotesToOvercomeNo    float64 `env:"YES_VOTES_TO_OVERCOME_NO"`   // 50% "yes" votes to overcome one "No vote"
+	BlocksToReject          int     `env:"BLOCKS_TO_REJECT"`           // Blocks which always reject, 0 disables blocks
+}
+
+// Policies holds the voting policy for each nominee role.
+// Both are parsed from the unprefixed variables first, then the seeder one
+// is overridden by the SEEDER_ prefixed variables which are set.
+type Policies struct {
+	Member Policy
+	Seeder Policy
+}
+
+func parseSeederPolicy(policies *Policies) error {
+	return parseEnv(&policies.Seeder, env.Options{Prefix: "SEEDER_"})
+}
@@ -1,5 +1,50 @@
 package configs
 
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
 type VoteAPI struct {
-	URL string `env:"VOTE_API_URL" envDefault:"http://acs-vote-bot-api:8000"`
+	URL              string        `env:"VOTE_API_URL" envDefault:"http://acs-vote-bot-api:8000"`
+	Timeout          time.Duration `env:"VOTE_API_TIMEOUT" envDefault:"10s"` // of a single request
+	MaxRetries       int           `env:"VOTE_API_MAX_RETRIES" envDefault:"3"`
+	RetryDelay       time.Duration `env:"VOTE_API_RETRY_DELAY" envDefault:"500ms"` // doubled after every failed attempt
+	BreakerThreshold int           `env:"VOTE_API_BREAKER_THRESHOLD" envDefault:"5"`
+	BreakerCooldown  time.Duration `env:"VOTE_API_BREAKER_COOLDOWN" envDefault:"30s"`
+}
+
+const (
+	VotesSourceAPI      = "api"      // the votes are requested from the vote API
+	VotesSourceWebhook  = "webhook"  // the votes are stored from the events the vote API posts
+	VotesSourceTelegram = "telegram" // the polls are native Telegram polls posted by the access governance bot
+)
+
+type Votes struct {
+	Source        string `env:"VOTES_SOURCE" envDefault:"api"`
+	WebhookSecret string `env:"VOTES_WEBHOOK_SECRET"`
+}
+
+// Anonymous tells whether the seeders can see only the counts of the votes, the native Telegram polls
+// show every seeder in the chat who voted for which option.
+func (v Votes) Anonymous() bool {
+	return v.Source != VotesSourceTelegram
+}
+
+func (v Votes) validate() error {
+	switch v.Source {
+	case VotesSourceAPI, VotesSourceWebhook, VotesSourceTelegram:
+		return nil
+	default:
+		return fmt.Errorf("unknown votes source %q", v.Source)
+	}
+}
+
+// validateWebhook checks the vote webhook can be served, only the proposal state service serves it.
+func (v Votes) validateWebhook() error {
+	if v.Source == VotesSourceWebhook && v.WebhookSecret == "" {
+		return errors.New("VOTES_WEBHOOK_SECRET is required for the webhook votes source")
+	}
+	return nil
 }
@@ -27,15 +27,11 @@ func (d dbLogger) AfterQuery(c context.Context, q *pg.QueryEvent) error {
 }
 
 func StartDB(config configs.DB, logger *zap.SugaredLogger) (*pg.DB, error) {
-	options, err := pg.ParseURL(config.URL)
+	db, err := ConnectDB(config, logger)
 	if err != nil {
-		logger.Errorw("failed to parse db url", "error", err)
 		return nil, err
 	}
 
-	db := pg.Connect(options)
-	db.AddQueryHook(dbLogger{logger})
-
 	collection := migrations.NewCollection()
 
 	err = collection.DiscoverSQLMigrations("migrations")
@@ -66,3 +62,17 @@ func StartDB(config configs.DB, logger *zap.SugaredLogger) (*pg.DB, error) {
 
 	return db, nil
 }
+
+// ConnectDB connects to the database without running the migrations.
+func ConnectDB(config configs.DB, logger *zap.SugaredLogger) (*pg.DB, error) {
+	options, err := pg.ParseURL(config.URL)
+	if err != nil {
+		logger.Errorw("failed to parse db url", "error", err)
+		return nil, err
+	}
+
+	db := pg.Connect(options)
+	db.AddQueryHook(dbLogger{logger})
+
+	return db, nil
+}
@@ -0,0 +1,34 @@
+package db
+
+import (
+	"context"
+
+	"github.com/go-pg/pg/v10"
+)
+
+// Advisory lock keys, one per job that must not run in several instances at once.
+const (
+	ProposalStateServiceLockKey int64 = 7_310_001
+	OutboxDispatcherLockKey     int64 = 7_310_002
+)
+
+// TryLock takes a session level advisory lock on a dedicated connection without waiting for it.
+// If the lock is taken, unlock releases it and closes the connection, the lock is also released
+// when the connection is lost, so a crashed instance does not keep it.
+func TryLock(db *pg.DB, key int64) (unlock func() error, locked bool, err error) {
+	conn := db.Conn()
+
+	_, err = conn.QueryOneContext(context.Background(), pg.Scan(&locked), "SELECT pg_try_advisory_lock(?)", key)
+	if err != nil || !locked {
+		_ = conn.Close()
+		return nil, false, err
+	}
+
+	unlock = func() error {
+		defer conn.Close()
+		_, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock(?)", key)
+		return err
+	}
+
+	return unlock, true, nil
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+type JobRunStatus string
+
+func (s JobRunStatus) String() string {
+	return string(s)
+}
+
+const (
+	JobRunStatusSucceeded JobRunStatus = "succeeded"
+	JobRunStatusFailed    JobRunStatus = "failed"
+)
+
+// JobRun is the summary of a single run of a scheduled job.
+// Processed items were changed, skipped ones needed no change and failed ones are retried on the next run.
+type JobRun struct {
+	ID         int          `json:"-" pg:",pk"`
+	Name       string       `json:"name" pg:",notnull"`
+	Status     JobRunStatus `json:"status" pg:",notnull"`
+	Processed  int          `json:"processed" pg:",use_zero"`
+	Failed     int          `json:"failed" pg:",use_zero"`
+	Skipped    int          `json:"skipped" pg:",use_zero"`
+	Error      string       `json:"error,omitempty" pg:",use_zero"`
+	StartedAt  time.Time    `json:"started_at" pg:",notnull"`
+	FinishedAt time.Time    `json:"finished_at" pg:"default:now()"`
+}
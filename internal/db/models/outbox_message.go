@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+type OutboxMessageStatus string
+
+func (s OutboxMessageStatus) String() string {
+	return string(s)
+}
+
+const (
+	OutboxMessageStatusPending OutboxMessageStatus = "pending"
+	OutboxMessageStatusSent    OutboxMessageStatus = "sent"
+	OutboxMessageStatusFailed  OutboxMessageStatus = "failed"
+
+	// OutboxInviteLinkPlaceholder is replaced in the text by the invite link created right before sending.
+	OutboxInviteLinkPlaceholder = "{{invite_link}}"
+)
+
+// OutboxInviteLink describes a one-time invite link to create for a message,
+// it is created on delivery, so a link is not wasted on a message that is never sent.
+type OutboxInviteLink struct {
+	ChatID                    int64  `json:"chat_id"`
+	NominatorTelegramNickname string `json:"nominator_telegram_nickname"`
+	NomineeTelegramNickname   string `json:"nominee_telegram_nickname"`
+}
+
+// OutboxMessage is a Telegram message waiting to be delivered, it is written
+// in the same transaction as the change it notifies about.
+type OutboxMessage struct {
+	ID                    int                    `json:"id" pg:",pk"`
+	ChatID                int64                  `json:"chat_id" pg:",notnull"`
+	Text                  string                 `json:"text" pg:",notnull"`
+	ParseMode             string                 `json:"parse_mode" pg:",use_zero"`
+	ReplyToMessageID      int                    `json:"reply_to_message_id" pg:",use_zero"`
+	DisableWebPagePreview bool                   `json:"disable_web_page_preview" pg:",use_zero"`
+	ReplyMarkup           map[string]interface{} `json:"reply_markup"`
+	InviteLink            *OutboxInviteLink      `json:"invite_link"`
+	Status                OutboxMessageStatus    `json:"status" pg:"default:'pending'"`
+	Attempts              int                    `json:"attempts" pg:",use_zero"`
+	LastError             string                 `json:"last_error" pg:",use_zero"`
+	NextAttemptAt         time.Time              `json:"next_attempt_at" pg:"default:now()"`
+	CreatedAt             time.Time              `json:"created_at" pg:"default:now()"`
+	SentAt                time.Time              `json:"sent_at"`
+}
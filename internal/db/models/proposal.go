@@ -16,10 +16,11 @@ func (r NomineeRole) String() string {
 }
 
 const (
-	ProposalStatusCreated  ProposalStatus = "created"
-	ProposalStatusApproved ProposalStatus = "approved"
-	ProposalStatusRejected ProposalStatus = "rejected"
-	ProposalStatusNoQuorum ProposalStatus = "no_quorum"
+	ProposalStatusCreated   ProposalStatus = "created"
+	ProposalStatusApproved  ProposalStatus = "approved"
+	ProposalStatusRejected  ProposalStatus = "rejected"
+	ProposalStatusNoQuorum  ProposalStatus = "no_quorum"
+	ProposalStatusWithdrawn ProposalStatus = "withdrawn" // taken back by the nominator before the voting finished
 
 	NomineeRoleMember NomineeRole = "member"
 	NomineeRoleSeeder NomineeRole = "seeder"
@@ -32,6 +33,19 @@ type Poll struct {
 	DiscussionMessageID int `json:"discussion_message_id"`
 }
 
+// VotingRules are the policy parameters a proposal was created with.
+type VotingRules struct {
+	VotingDurationDays      int     `json:"voting_duration_days"`
+	VotingExtensionDays     int     `json:"voting_extension_days"`
+	MaxVotingExtensions     int     `json:"max_voting_extensions"`
+	Quorum                  float64 `json:"quorum"`
+	MaxRequiredSeedersCount float64 `json:"max_required_seeders_count"`
+	MinYesVotesPercentage   float64 `json:"min_yes_votes_percentage"`
+	MinRequiredYesVotes     float64 `json:"min_required_yes_votes"`
+	YesVotesToOvercomeNo    float64 `json:"yes_votes_to_overcome_no"`
+	BlocksToReject          int     `json:"blocks_to_reject"`
+}
+
 type Proposal struct {
 	ID                      int            `json:"id" pg:",pk,default:gen_random_uuid()"`
 	NominatorID             int            `json:"nominator_id" pg:",notnull"`
@@ -43,4 +57,8 @@ type Proposal struct {
 	Status                  ProposalStatus `json:"status" pg:"type:ProposalStatus,notnull,default:'created'"`
 	CreatedAt               time.Time      `json:"created_at" pg:"default:now()"`
 	FinishedAt              time.Time      `json:"finished_at"`
+	VotingRules             *VotingRules   `json:"voting_rules"`
+	SeedersCount            int            `json:"seeders_count" pg:",use_zero"`
+	ExtensionsCount         int            `json:"extensions_count" pg:",use_zero"`
+	PollClosedAt            *time.Time     `json:"poll_closed_at"` // nil while votes can still be cast in the poll
 }
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// ProposalBlock is a blocking objection of a seeder against a proposal.
+// The reason is shared with the other seeders, the author is not.
+type ProposalBlock struct {
+	ID         int       `json:"id" pg:",pk"`
+	ProposalID int       `json:"proposal_id" pg:",notnull"`
+	UserID     int       `json:"-" pg:",notnull"`
+	Reason     string    `json:"reason" pg:",notnull"`
+	CreatedAt  time.Time `json:"created_at" pg:"default:now()"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// ProposalReminder is a reminder sent to a seeder who has not voted on a proposal yet.
+type ProposalReminder struct {
+	ID              int       `json:"id" pg:",pk"`
+	ProposalID      int       `json:"proposal_id" pg:",notnull"`
+	UserID          int       `json:"user_id" pg:",notnull"`
+	OffsetDays      int       `json:"offset_days" pg:",use_zero"`
+	ExtensionsCount int       `json:"extensions_count" pg:",use_zero"`
+	SentAt          time.Time `json:"sent_at" pg:"default:now()"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+type DecisionReason string
+
+func (r DecisionReason) String() string {
+	return string(r)
+}
+
+const (
+	DecisionReasonApproved             DecisionReason = "approved"
+	DecisionReasonBlocked              DecisionReason = "blocked"
+	DecisionReasonNoQuorum             DecisionReason = "no_quorum"
+	DecisionReasonInsufficientYesVotes DecisionReason = "insufficient_yes_votes"
+	DecisionReasonOverriddenByNoVotes  DecisionReason = "overridden_by_no_votes"
+)
+
+// ProposalResult is the final tally of a decided proposal.
+// VoterIDs are never serialized to keep the votes anonymous.
+type ProposalResult struct {
+	ID                            int            `json:"id" pg:",pk"`
+	ProposalID                    int            `json:"proposal_id" pg:",notnull"`
+	YesVotes                      int            `json:"yes_votes" pg:",use_zero"`
+	NoVotes                       int            `json:"no_votes" pg:",use_zero"`
+	AbstainVotes                  int            `json:"abstain_votes" pg:",use_zero"`
+	BlockVotes                    int            `json:"block_votes" pg:",use_zero"`
+	VotedSeedersCount             int            `json:"voted_seeders_count" pg:",use_zero"`
+	TotalSeedersCount             int            `json:"total_seeders_count" pg:",use_zero"`
+	MinRequiredSeedersCount       int            `json:"min_required_seeders_count" pg:",use_zero"`
+	MinRequiredYesVotes           int            `json:"min_required_yes_votes" pg:",use_zero"`
+	MinRequiredYesVotesToOverride int            `json:"min_required_yes_votes_to_override" pg:",use_zero"`
+	VoterIDs                      []int64        `json:"-" pg:",array,use_zero"`
+	DecidedEarly                  bool           `json:"decided_early" pg:",use_zero"`
+	Reason                        DecisionReason `json:"reason" pg:",use_zero"`
+	CreatedAt                     time.Time      `json:"created_at" pg:"default:now()"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// TelegramPoll is a native Telegram poll the access governance bot posted for a proposal,
+// its ID is the poll ID the proposal and the votes refer to.
+type TelegramPoll struct {
+	ID                   int       `json:"id" pg:",pk"`
+	TelegramPollID       string    `json:"telegram_poll_id" pg:",notnull"`
+	ChatID               int64     `json:"chat_id" pg:",notnull"`
+	MessageID            int       `json:"message_id" pg:",notnull"`
+	Description          string    `json:"description" pg:",use_zero"`            // without the deadline, empty for the polls posted before it was stored
+	DescriptionMessageID int       `json:"description_message_id" pg:",use_zero"` // the message the poll replies to
+	Closed               bool      `json:"closed" pg:",use_zero"`
+	CreatedAt            time.Time `json:"created_at" pg:"default:now()"`
+}
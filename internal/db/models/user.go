@@ -24,7 +24,7 @@ type User struct {
 	TelegramNickname      string        `json:"telegram_nickname" pg:",notnull,unique"`
 	DiscordID             int           `json:"discord_id"`
 	Role                  UserRole      `json:"role" pg:"type:UserRole,notnull,default:'guest'"`
-	Proposals             []Proposal    `json:"proposals" pg:"rel:has-many,fk:user_id"`
+	Proposals             []Proposal    `json:"proposals" pg:"rel:has-many,join_fk:nominator_id"`
 	BackersID             []int64       `json:"backers_id" pg:",array"`
 	NominatorID           int           `json:"nominator_id"`
 	MembersChatInviteLink string        `json:"members_chat_invite_link"`
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+type VoteSource string
+
+const (
+	VoteSourceWebhook  VoteSource = "webhook"  // posted by the vote API, the poll id is the one of the vote API
+	VoteSourceTelegram VoteSource = "telegram" // answered to a native Telegram poll, the poll id is the one of TelegramPoll
+)
+
+// Vote is the latest vote of a user in a poll as received from the vote webhook or the Telegram poll,
+// a retracted vote is kept with an empty option, so an older event cannot bring it back.
+type Vote struct {
+	ID         int        `json:"-" pg:",pk"`
+	Source     VoteSource `json:"source" pg:",notnull"`
+	PollID     int        `json:"poll_id" pg:",notnull"`
+	UserID     int64      `json:"user_id" pg:",notnull"`
+	Option     string     `json:"option" pg:",use_zero"`
+	VotedAt    time.Time  `json:"voted_at" pg:",notnull"`
+	ReceivedAt time.Time  `json:"received_at" pg:"default:now()"`
+}
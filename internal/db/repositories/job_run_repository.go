@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"access_governance_system/internal/db/models"
+	"errors"
+
+	"github.com/go-pg/pg/v10"
+)
+
+type jobRunRepository struct {
+	repository
+}
+
+type JobRunRepository interface {
+	Create(request *models.JobRun) error
+	GetLastByName(name string, status ...models.JobRunStatus) (*models.JobRun, error)
+}
+
+func NewJobRunRepository(db *pg.DB) JobRunRepository {
+	return &jobRunRepository{
+		repository: repository{
+			db: db,
+		},
+	}
+}
+
+func (r *jobRunRepository) Create(request *models.JobRun) error {
+	_, err := r.db.Model(request).Insert()
+	return err
+}
+
+// GetLastByName returns the latest run of the job, only with one of the statuses if any are given.
+func (r *jobRunRepository) GetLastByName(name string, status ...models.JobRunStatus) (*models.JobRun, error) {
+	run := &models.JobRun{}
+
+	query := r.db.Model(run).
+		Where("name = ?", name)
+	if len(status) > 0 {
+		query = query.WhereIn("status IN (?)", status)
+	}
+
+	err := query.
+		Order("finished_at DESC").
+		Limit(1).
+		Select()
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return run, err
+}
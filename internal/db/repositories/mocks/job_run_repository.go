@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /Users/ben/Projects/access governance system/internal/db/repositories/job_run_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=/Users/ben/Projects/access governance system/internal/db/repositories/job_run_repository.go -destination=/Users/ben/Projects/access governance system/internal/db/repositories/mocks/job_run_repository.go
+//
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	models "access_governance_system/internal/db/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockJobRunRepository is a mock of JobRunRepository interface.
+type MockJobRunRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobRunRepositoryMockRecorder
+}
+
+// MockJobRunRepositoryMockRecorder is the mock recorder for MockJobRunRepository.
+type MockJobRunRepositoryMockRecorder struct {
+	mock *MockJobRunRepository
+}
+
+// NewMockJobRunRepository creates a new mock instance.
+func NewMockJobRunRepository(ctrl *gomock.Controller) *MockJobRunRepository {
+	mock := &MockJobRunRepository{ctrl: ctrl}
+	mock.recorder = &MockJobRunRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobRunRepository) EXPECT() *MockJobRunRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockJobRunRepository) Create(request *models.JobRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockJobRunRepositoryMockRecorder) Create(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockJobRunRepository)(nil).Create), request)
+}
+
+// GetLastByName mocks base method.
+func (m *MockJobRunRepository) GetLastByName(name string, status ...models.JobRunStatus) (*models.JobRun, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{name}
+	for _, a := range status {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetLastByName", varargs...)
+	ret0, _ := ret[0].(*models.JobRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastByName indicates an expected call of GetLastByName.
+func (mr *MockJobRunRepositoryMockRecorder) GetLastByName(name any, status ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{name}, status...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastByName", reflect.TypeOf((*MockJobRunRepository)(nil).GetLastByName), varargs...)
+}
@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /Users/ben/Projects/access governance system/internal/db/repositories/outbox_message_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=/Users/ben/Projects/access governance system/internal/db/repositories/outbox_message_repository.go -destination=/Users/ben/Projects/access governance system/internal/db/repositories/mocks/outbox_message_repository.go
+//
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	models "access_governance_system/internal/db/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOutboxMessageRepository is a mock of OutboxMessageRepository interface.
+type MockOutboxMessageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxMessageRepositoryMockRecorder
+}
+
+// MockOutboxMessageRepositoryMockRecorder is the mock recorder for MockOutboxMessageRepository.
+type MockOutboxMessageRepositoryMockRecorder struct {
+	mock *MockOutboxMessageRepository
+}
+
+// NewMockOutboxMessageRepository creates a new mock instance.
+func NewMockOutboxMessageRepository(ctrl *gomock.Controller) *MockOutboxMessageRepository {
+	mock := &MockOutboxMessageRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxMessageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxMessageRepository) EXPECT() *MockOutboxMessageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByStatus mocks base method.
+func (m *MockOutboxMessageRepository) CountByStatus(status models.OutboxMessageStatus) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByStatus", status)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByStatus indicates an expected call of CountByStatus.
+func (mr *MockOutboxMessageRepositoryMockRecorder) CountByStatus(status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByStatus", reflect.TypeOf((*MockOutboxMessageRepository)(nil).CountByStatus), status)
+}
+
+// GetManyDue mocks base method.
+func (m *MockOutboxMessageRepository) GetManyDue(limit int) ([]*models.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManyDue", limit)
+	ret0, _ := ret[0].([]*models.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManyDue indicates an expected call of GetManyDue.
+func (mr *MockOutboxMessageRepositoryMockRecorder) GetManyDue(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyDue", reflect.TypeOf((*MockOutboxMessageRepository)(nil).GetManyDue), limit)
+}
+
+// Update mocks base method.
+func (m *MockOutboxMessageRepository) Update(request *models.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockOutboxMessageRepositoryMockRecorder) Update(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOutboxMessageRepository)(nil).Update), request)
+}
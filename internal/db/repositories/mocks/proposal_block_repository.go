@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /Users/ben/Projects/access governance system/internal/db/repositories/proposal_block_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=/Users/ben/Projects/access governance system/internal/db/repositories/proposal_block_repository.go -destination=/Users/ben/Projects/access governance system/internal/db/repositories/mocks/proposal_block_repository.go
+//
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	models "access_governance_system/internal/db/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProposalBlockRepository is a mock of ProposalBlockRepository interface.
+type MockProposalBlockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProposalBlockRepositoryMockRecorder
+}
+
+// MockProposalBlockRepositoryMockRecorder is the mock recorder for MockProposalBlockRepository.
+type MockProposalBlockRepositoryMockRecorder struct {
+	mock *MockProposalBlockRepository
+}
+
+// NewMockProposalBlockRepository creates a new mock instance.
+func NewMockProposalBlockRepository(ctrl *gomock.Controller) *MockProposalBlockRepository {
+	mock := &MockProposalBlockRepository{ctrl: ctrl}
+	mock.recorder = &MockProposalBlockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProposalBlockRepository) EXPECT() *MockProposalBlockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockProposalBlockRepository) Create(request *models.ProposalBlock) (*models.ProposalBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", request)
+	ret0, _ := ret[0].(*models.ProposalBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockProposalBlockRepositoryMockRecorder) Create(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProposalBlockRepository)(nil).Create), request)
+}
+
+// GetManyByProposalID mocks base method.
+func (m *MockProposalBlockRepository) GetManyByProposalID(proposalID int) ([]*models.ProposalBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManyByProposalID", proposalID)
+	ret0, _ := ret[0].([]*models.ProposalBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManyByProposalID indicates an expected call of GetManyByProposalID.
+func (mr *MockProposalBlockRepositoryMockRecorder) GetManyByProposalID(proposalID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyByProposalID", reflect.TypeOf((*MockProposalBlockRepository)(nil).GetManyByProposalID), proposalID)
+}
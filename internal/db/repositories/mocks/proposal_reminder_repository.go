@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /Users/ben/Projects/access governance system/internal/db/repositories/proposal_reminder_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=/Users/ben/Projects/access governance system/internal/db/repositories/proposal_reminder_repository.go -destination=/Users/ben/Projects/access governance system/internal/db/repositories/mocks/proposal_reminder_repository.go
+//
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	models "access_governance_system/internal/db/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProposalReminderRepository is a mock of ProposalReminderRepository interface.
+type MockProposalReminderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProposalReminderRepositoryMockRecorder
+}
+
+// MockProposalReminderRepositoryMockRecorder is the mock recorder for MockProposalReminderRepository.
+type MockProposalReminderRepositoryMockRecorder struct {
+	mock *MockProposalReminderRepository
+}
+
+// NewMockProposalReminderRepository creates a new mock instance.
+func NewMockProposalReminderRepository(ctrl *gomock.Controller) *MockProposalReminderRepository {
+	mock := &MockProposalReminderRepository{ctrl: ctrl}
+	mock.recorder = &MockProposalReminderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProposalReminderRepository) EXPECT() *MockProposalReminderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockProposalReminderRepository) Create(request *models.ProposalReminder, message *models.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", request, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockProposalReminderRepositoryMockRecorder) Create(request, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProposalReminderRepository)(nil).Create), request, message)
+}
+
+// GetManyByProposalID mocks base method.
+func (m *MockProposalReminderRepository) GetManyByProposalID(proposalID int) ([]*models.ProposalReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManyByProposalID", proposalID)
+	ret0, _ := ret[0].([]*models.ProposalReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManyByProposalID indicates an expected call of GetManyByProposalID.
+func (mr *MockProposalReminderRepositoryMockRecorder) GetManyByProposalID(proposalID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyByProposalID", reflect.TypeOf((*MockProposalReminderRepository)(nil).GetManyByProposalID), proposalID)
+}
@@ -38,6 +38,21 @@ func (m *MockProposalRepository) EXPECT() *MockProposalRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountByStatus mocks base method.
+func (m *MockProposalRepository) CountByStatus() (map[models.ProposalStatus]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByStatus")
+	ret0, _ := ret[0].(map[models.ProposalStatus]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByStatus indicates an expected call of CountByStatus.
+func (mr *MockProposalRepositoryMockRecorder) CountByStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByStatus", reflect.TypeOf((*MockProposalRepository)(nil).CountByStatus))
+}
+
 // Create mocks base method.
 func (m *MockProposalRepository) Create(request *models.Proposal) (*models.Proposal, error) {
 	m.ctrl.T.Helper()
@@ -67,6 +82,71 @@ func (mr *MockProposalRepositoryMockRecorder) Delete(request any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProposalRepository)(nil).Delete), request)
 }
 
+// Extend mocks base method.
+func (m *MockProposalRepository) Extend(request *models.Proposal, messages []*models.OutboxMessage) (*models.Proposal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Extend", request, messages)
+	ret0, _ := ret[0].(*models.Proposal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Extend indicates an expected call of Extend.
+func (mr *MockProposalRepositoryMockRecorder) Extend(request, messages any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Extend", reflect.TypeOf((*MockProposalRepository)(nil).Extend), request, messages)
+}
+
+// Finalize mocks base method.
+func (m *MockProposalRepository) Finalize(request *models.Proposal, result *models.ProposalResult, nominee *models.User, messages []*models.OutboxMessage) (*models.Proposal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finalize", request, result, nominee, messages)
+	ret0, _ := ret[0].(*models.Proposal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Finalize indicates an expected call of Finalize.
+func (mr *MockProposalRepositoryMockRecorder) Finalize(request, result, nominee, messages any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finalize", reflect.TypeOf((*MockProposalRepository)(nil).Finalize), request, result, nominee, messages)
+}
+
+// GetApprovedByNomineeNickname mocks base method.
+func (m *MockProposalRepository) GetApprovedByNomineeNickname(nomineeNickName string) (*models.Proposal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApprovedByNomineeNickname", nomineeNickName)
+	ret0, _ := ret[0].(*models.Proposal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApprovedByNomineeNickname indicates an expected call of GetApprovedByNomineeNickname.
+func (mr *MockProposalRepositoryMockRecorder) GetApprovedByNomineeNickname(nomineeNickName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApprovedByNomineeNickname", reflect.TypeOf((*MockProposalRepository)(nil).GetApprovedByNomineeNickname), nomineeNickName)
+}
+
+// GetManyByNominatorID mocks base method.
+func (m *MockProposalRepository) GetManyByNominatorID(nominatorID int, status ...models.ProposalStatus) ([]*models.Proposal, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{nominatorID}
+	for _, a := range status {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetManyByNominatorID", varargs...)
+	ret0, _ := ret[0].([]*models.Proposal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManyByNominatorID indicates an expected call of GetManyByNominatorID.
+func (mr *MockProposalRepositoryMockRecorder) GetManyByNominatorID(nominatorID any, status ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{nominatorID}, status...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyByNominatorID", reflect.TypeOf((*MockProposalRepository)(nil).GetManyByNominatorID), varargs...)
+}
+
 // GetManyByNomineeNickname mocks base method.
 func (m *MockProposalRepository) GetManyByNomineeNickname(nomineeNickName string) ([]*models.Proposal, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +181,21 @@ func (mr *MockProposalRepositoryMockRecorder) GetManyByStatus(status ...any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyByStatus", reflect.TypeOf((*MockProposalRepository)(nil).GetManyByStatus), status...)
 }
 
+// GetManyWithOpenPoll mocks base method.
+func (m *MockProposalRepository) GetManyWithOpenPoll() ([]*models.Proposal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManyWithOpenPoll")
+	ret0, _ := ret[0].([]*models.Proposal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManyWithOpenPoll indicates an expected call of GetManyWithOpenPoll.
+func (mr *MockProposalRepositoryMockRecorder) GetManyWithOpenPoll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyWithOpenPoll", reflect.TypeOf((*MockProposalRepository)(nil).GetManyWithOpenPoll))
+}
+
 // GetOneByID mocks base method.
 func (m *MockProposalRepository) GetOneByID(id int64) (*models.Proposal, error) {
 	m.ctrl.T.Helper()
@@ -116,6 +211,20 @@ func (mr *MockProposalRepositoryMockRecorder) GetOneByID(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByID", reflect.TypeOf((*MockProposalRepository)(nil).GetOneByID), id)
 }
 
+// MarkPollClosed mocks base method.
+func (m *MockProposalRepository) MarkPollClosed(request *models.Proposal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPollClosed", request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPollClosed indicates an expected call of MarkPollClosed.
+func (mr *MockProposalRepositoryMockRecorder) MarkPollClosed(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPollClosed", reflect.TypeOf((*MockProposalRepository)(nil).MarkPollClosed), request)
+}
+
 // Update mocks base method.
 func (m *MockProposalRepository) Update(request *models.Proposal) (*models.Proposal, error) {
 	m.ctrl.T.Helper()
@@ -130,3 +239,18 @@ func (mr *MockProposalRepositoryMockRecorder) Update(request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProposalRepository)(nil).Update), request)
 }
+
+// Withdraw mocks base method.
+func (m *MockProposalRepository) Withdraw(request *models.Proposal, messages []*models.OutboxMessage) (*models.Proposal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Withdraw", request, messages)
+	ret0, _ := ret[0].(*models.Proposal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Withdraw indicates an expected call of Withdraw.
+func (mr *MockProposalRepositoryMockRecorder) Withdraw(request, messages any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockProposalRepository)(nil).Withdraw), request, messages)
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /Users/ben/Projects/access governance system/internal/db/repositories/proposal_result_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=/Users/ben/Projects/access governance system/internal/db/repositories/proposal_result_repository.go -destination=/Users/ben/Projects/access governance system/internal/db/repositories/mocks/proposal_result_repository.go
+//
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	models "access_governance_system/internal/db/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProposalResultRepository is a mock of ProposalResultRepository interface.
+type MockProposalResultRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProposalResultRepositoryMockRecorder
+}
+
+// MockProposalResultRepositoryMockRecorder is the mock recorder for MockProposalResultRepository.
+type MockProposalResultRepositoryMockRecorder struct {
+	mock *MockProposalResultRepository
+}
+
+// NewMockProposalResultRepository creates a new mock instance.
+func NewMockProposalResultRepository(ctrl *gomock.Controller) *MockProposalResultRepository {
+	mock := &MockProposalResultRepository{ctrl: ctrl}
+	mock.recorder = &MockProposalResultRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProposalResultRepository) EXPECT() *MockProposalResultRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetOneByProposalID mocks base method.
+func (m *MockProposalResultRepository) GetOneByProposalID(proposalID int) (*models.ProposalResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneByProposalID", proposalID)
+	ret0, _ := ret[0].(*models.ProposalResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneByProposalID indicates an expected call of GetOneByProposalID.
+func (mr *MockProposalResultRepositoryMockRecorder) GetOneByProposalID(proposalID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByProposalID", reflect.TypeOf((*MockProposalResultRepository)(nil).GetOneByProposalID), proposalID)
+}
@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /Users/ben/Projects/access governance system/internal/db/repositories/telegram_poll_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=/Users/ben/Projects/access governance system/internal/db/repositories/telegram_poll_repository.go -destination=/Users/ben/Projects/access governance system/internal/db/repositories/mocks/telegram_poll_repository.go
+//
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	models "access_governance_system/internal/db/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTelegramPollRepository is a mock of TelegramPollRepository interface.
+type MockTelegramPollRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTelegramPollRepositoryMockRecorder
+}
+
+// MockTelegramPollRepositoryMockRecorder is the mock recorder for MockTelegramPollRepository.
+type MockTelegramPollRepositoryMockRecorder struct {
+	mock *MockTelegramPollRepository
+}
+
+// NewMockTelegramPollRepository creates a new mock instance.
+func NewMockTelegramPollRepository(ctrl *gomock.Controller) *MockTelegramPollRepository {
+	mock := &MockTelegramPollRepository{ctrl: ctrl}
+	mock.recorder = &MockTelegramPollRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTelegramPollRepository) EXPECT() *MockTelegramPollRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTelegramPollRepository) Create(request *models.TelegramPoll) (*models.TelegramPoll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", request)
+	ret0, _ := ret[0].(*models.TelegramPoll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTelegramPollRepositoryMockRecorder) Create(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTelegramPollRepository)(nil).Create), request)
+}
+
+// GetOneByID mocks base method.
+func (m *MockTelegramPollRepository) GetOneByID(id int) (*models.TelegramPoll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneByID", id)
+	ret0, _ := ret[0].(*models.TelegramPoll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneByID indicates an expected call of GetOneByID.
+func (mr *MockTelegramPollRepositoryMockRecorder) GetOneByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByID", reflect.TypeOf((*MockTelegramPollRepository)(nil).GetOneByID), id)
+}
+
+// GetOneByTelegramPollID mocks base method.
+func (m *MockTelegramPollRepository) GetOneByTelegramPollID(telegramPollID string) (*models.TelegramPoll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneByTelegramPollID", telegramPollID)
+	ret0, _ := ret[0].(*models.TelegramPoll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneByTelegramPollID indicates an expected call of GetOneByTelegramPollID.
+func (mr *MockTelegramPollRepositoryMockRecorder) GetOneByTelegramPollID(telegramPollID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByTelegramPollID", reflect.TypeOf((*MockTelegramPollRepository)(nil).GetOneByTelegramPollID), telegramPollID)
+}
+
+// Update mocks base method.
+func (m *MockTelegramPollRepository) Update(request *models.TelegramPoll) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTelegramPollRepositoryMockRecorder) Update(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTelegramPollRepository)(nil).Update), request)
+}
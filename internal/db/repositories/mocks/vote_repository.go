@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /Users/ben/Projects/access governance system/internal/db/repositories/vote_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=/Users/ben/Projects/access governance system/internal/db/repositories/vote_repository.go -destination=/Users/ben/Projects/access governance system/internal/db/repositories/mocks/vote_repository.go
+//
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	models "access_governance_system/internal/db/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVoteRepository is a mock of VoteRepository interface.
+type MockVoteRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVoteRepositoryMockRecorder
+}
+
+// MockVoteRepositoryMockRecorder is the mock recorder for MockVoteRepository.
+type MockVoteRepositoryMockRecorder struct {
+	mock *MockVoteRepository
+}
+
+// NewMockVoteRepository creates a new mock instance.
+func NewMockVoteRepository(ctrl *gomock.Controller) *MockVoteRepository {
+	mock := &MockVoteRepository{ctrl: ctrl}
+	mock.recorder = &MockVoteRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVoteRepository) EXPECT() *MockVoteRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetManyByPollID mocks base method.
+func (m *MockVoteRepository) GetManyByPollID(source models.VoteSource, pollID int) ([]*models.Vote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManyByPollID", source, pollID)
+	ret0, _ := ret[0].([]*models.Vote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManyByPollID indicates an expected call of GetManyByPollID.
+func (mr *MockVoteRepositoryMockRecorder) GetManyByPollID(source, pollID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyByPollID", reflect.TypeOf((*MockVoteRepository)(nil).GetManyByPollID), source, pollID)
+}
+
+// Save mocks base method.
+func (m *MockVoteRepository) Save(request *models.Vote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockVoteRepositoryMockRecorder) Save(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockVoteRepository)(nil).Save), request)
+}
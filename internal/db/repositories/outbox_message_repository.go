@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"access_governance_system/internal/db/models"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+)
+
+type outboxMessageRepository struct {
+	repository
+}
+
+type OutboxMessageRepository interface {
+	Update(request *models.OutboxMessage) error
+	GetManyDue(limit int) ([]*models.OutboxMessage, error)
+	CountByStatus(status models.OutboxMessageStatus) (int, error)
+}
+
+func NewOutboxMessageRepository(db *pg.DB) OutboxMessageRepository {
+	return &outboxMessageRepository{
+		repository: repository{
+			db: db,
+		},
+	}
+}
+
+func (r *outboxMessageRepository) Update(request *models.OutboxMessage) error {
+	_, err := r.db.Model(request).WherePK().Update()
+	return err
+}
+
+// GetManyDue returns the pending messages whose next attempt is due, the oldest first.
+func (r *outboxMessageRepository) GetManyDue(limit int) ([]*models.OutboxMessage, error) {
+	messages := make([]*models.OutboxMessage, 0)
+
+	err := r.db.Model(&messages).
+		Where("status = ?", models.OutboxMessageStatusPending).
+		Where("next_attempt_at <= now()").
+		Order("id ASC").
+		Limit(limit).
+		Select()
+
+	return messages, err
+}
+
+func (r *outboxMessageRepository) CountByStatus(status models.OutboxMessageStatus) (int, error) {
+	return r.db.Model((*models.OutboxMessage)(nil)).
+		Where("status = ?", status).
+		Count()
+}
+
+// insertOutboxMessages writes the messages in the transaction of the change they notify about.
+func insertOutboxMessages(db orm.DB, messages []*models.OutboxMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	_, err := db.Model(&messages).Insert()
+	return err
+}
@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"access_governance_system/internal/db/models"
+
+	"github.com/go-pg/pg/v10"
+)
+
+type proposalBlockRepository struct {
+	repository
+}
+
+type ProposalBlockRepository interface {
+	Create(request *models.ProposalBlock) (*models.ProposalBlock, error)
+	GetManyByProposalID(proposalID int) ([]*models.ProposalBlock, error)
+}
+
+func NewProposalBlockRepository(db *pg.DB) ProposalBlockRepository {
+	return &proposalBlockRepository{
+		repository: repository{
+			db: db,
+		},
+	}
+}
+
+func (r *proposalBlockRepository) Create(request *models.ProposalBlock) (*models.ProposalBlock, error) {
+	_, err := r.db.Model(request).Insert()
+	if err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+func (r *proposalBlockRepository) GetManyByProposalID(proposalID int) ([]*models.ProposalBlock, error) {
+	blocks := make([]*models.ProposalBlock, 0)
+
+	err := r.db.Model(&blocks).
+		Where("proposal_id = ?", proposalID).
+		OrderExpr("created_at ASC").
+		Select()
+
+	return blocks, err
+}
@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"access_governance_system/internal/db/models"
+	"context"
+	"errors"
+
+	"github.com/go-pg/pg/v10"
+)
+
+type proposalReminderRepository struct {
+	repository
+}
+
+type ProposalReminderRepository interface {
+	Create(request *models.ProposalReminder, message *models.OutboxMessage) error
+	GetManyByProposalID(proposalID int) ([]*models.ProposalReminder, error)
+}
+
+func NewProposalReminderRepository(db *pg.DB) ProposalReminderRepository {
+	return &proposalReminderRepository{
+		repository: repository{
+			db: db,
+		},
+	}
+}
+
+// Create stores the reminder together with its message, a reminder which was already sent is skipped with its message.
+func (r *proposalReminderRepository) Create(request *models.ProposalReminder, message *models.OutboxMessage) error {
+	return r.db.RunInTransaction(context.Background(), func(tx *pg.Tx) error {
+		// The skipped insert returns no id, which go-pg reports as no rows.
+		_, err := tx.Model(request).
+			OnConflict("DO NOTHING").
+			Insert()
+		if errors.Is(err, pg.ErrNoRows) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		return insertOutboxMessages(tx, []*models.OutboxMessage{message})
+	})
+}
+
+func (r *proposalReminderRepository) GetManyByProposalID(proposalID int) ([]*models.ProposalReminder, error) {
+	reminders := make([]*models.ProposalReminder, 0)
+
+	err := r.db.Model(&reminders).
+		Where("proposal_id = ?", proposalID).
+		Select()
+
+	return reminders, err
+}
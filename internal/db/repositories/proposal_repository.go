@@ -2,11 +2,16 @@ package repositories
 
 import (
 	"access_governance_system/internal/db/models"
+	"context"
 	"errors"
+	"time"
 
 	"github.com/go-pg/pg/v10"
 )
 
+// ErrProposalAlreadyFinished is returned when a proposal was finalized or extended by someone else in the meantime.
+var ErrProposalAlreadyFinished = errors.New("proposal is already finished")
+
 type proposalRepository struct {
 	repository
 }
@@ -14,11 +19,23 @@ type proposalRepository struct {
 type ProposalRepository interface {
 	Create(request *models.Proposal) (*models.Proposal, error)
 	Update(request *models.Proposal) (*models.Proposal, error)
+	Finalize(
+		request *models.Proposal,
+		result *models.ProposalResult,
+		nominee *models.User,
+		messages []*models.OutboxMessage,
+	) (*models.Proposal, error)
+	Extend(request *models.Proposal, messages []*models.OutboxMessage) (*models.Proposal, error)
+	Withdraw(request *models.Proposal, messages []*models.OutboxMessage) (*models.Proposal, error)
 	Delete(request *models.Proposal) error
 	GetOneByID(id int64) (*models.Proposal, error)
 	GetManyByNomineeNickname(nomineeNickName string) ([]*models.Proposal, error)
 	GetApprovedByNomineeNickname(nomineeNickName string) (*models.Proposal, error)
 	GetManyByStatus(status ...models.ProposalStatus) ([]*models.Proposal, error)
+	GetManyByNominatorID(nominatorID int, status ...models.ProposalStatus) ([]*models.Proposal, error)
+	CountByStatus() (map[models.ProposalStatus]int, error)
+	GetManyWithOpenPoll() ([]*models.Proposal, error)
+	MarkPollClosed(request *models.Proposal) error
 }
 
 func NewProposalRepository(db *pg.DB) ProposalRepository {
@@ -65,6 +82,103 @@ func (r *proposalRepository) Update(request *models.Proposal) (*models.Proposal,
 	return proposal, err
 }
 
+// Finalize stores the decided status of the proposal together with its result and notifications in one transaction,
+// only a proposal that is still created can be finalized, so it is finalized once.
+// The nominee of an approved proposal is created, or promoted by its role and backers, in the same transaction,
+// a nil nominee leaves the users as they are.
+func (r *proposalRepository) Finalize(
+	request *models.Proposal,
+	result *models.ProposalResult,
+	nominee *models.User,
+	messages []*models.OutboxMessage,
+) (*models.Proposal, error) {
+	err := r.db.RunInTransaction(context.Background(), func(tx *pg.Tx) error {
+		res, err := tx.Model(request).
+			WherePK().
+			Where("status = ?", models.ProposalStatusCreated).
+			Update()
+		if err != nil {
+			return err
+		}
+		if res.RowsAffected() == 0 {
+			return ErrProposalAlreadyFinished
+		}
+
+		result.ProposalID = request.ID
+
+		if _, err = tx.Model(result).Insert(); err != nil {
+			return err
+		}
+
+		if nominee != nil {
+			if err = saveNominee(tx, nominee); err != nil {
+				return err
+			}
+		}
+
+		return insertOutboxMessages(tx, messages)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetOneByID(int64(request.ID))
+}
+
+// Extend stores the new deadline of the proposal together with its notifications, only if the proposal
+// is still created and was not extended by someone else since it was read.
+func (r *proposalRepository) Extend(request *models.Proposal, messages []*models.OutboxMessage) (*models.Proposal, error) {
+	err := r.db.RunInTransaction(context.Background(), func(tx *pg.Tx) error {
+		res, err := tx.Model(request).
+			Column("finished_at", "extensions_count").
+			WherePK().
+			Where("status = ?", models.ProposalStatusCreated).
+			Where("extensions_count = ?", request.ExtensionsCount-1).
+			Update()
+		if err != nil {
+			return err
+		}
+		if res.RowsAffected() == 0 {
+			return ErrProposalAlreadyFinished
+		}
+
+		return insertOutboxMessages(tx, messages)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetOneByID(int64(request.ID))
+}
+
+// Withdraw moves the proposal to the withdrawn status together with its notifications, only a proposal
+// that is still created can be withdrawn, so it cannot be withdrawn after it was finalized.
+func (r *proposalRepository) Withdraw(request *models.Proposal, messages []*models.OutboxMessage) (*models.Proposal, error) {
+	request.Status = models.ProposalStatusWithdrawn
+	request.FinishedAt = time.Now()
+
+	err := r.db.RunInTransaction(context.Background(), func(tx *pg.Tx) error {
+		res, err := tx.Model(request).
+			Column("status", "finished_at").
+			WherePK().
+			Where("status = ?", models.ProposalStatusCreated).
+			Update()
+		if err != nil {
+			return err
+		}
+		if res.RowsAffected() == 0 {
+			return ErrProposalAlreadyFinished
+		}
+
+		return insertOutboxMessages(tx, messages)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetOneByID(int64(request.ID))
+}
+
 func (r *proposalRepository) Delete(request *models.Proposal) error {
 	_, err := r.db.Model(request).WherePK().Delete()
 	return err
@@ -123,3 +237,83 @@ func (r *proposalRepository) GetManyByStatus(status ...models.ProposalStatus) ([
 
 	return proposals, err
 }
+
+// GetManyByNominatorID returns the proposals of the nominator with any of the statuses, oldest first.
+func (r *proposalRepository) GetManyByNominatorID(nominatorID int, status ...models.ProposalStatus) ([]*models.Proposal, error) {
+	proposals := make([]*models.Proposal, 0)
+
+	err := r.db.Model(&proposals).
+		Where("nominator_id = ?", nominatorID).
+		WhereGroup(func(q *pg.Query) (*pg.Query, error) {
+			for _, s := range status {
+				q = q.WhereOr("status = ?", s)
+			}
+			return q, nil
+		}).
+		OrderExpr("created_at ASC").
+		Select()
+
+	return proposals, err
+}
+
+// CountByStatus returns how many proposals there are of each status, the statuses without proposals are left out.
+func (r *proposalRepository) CountByStatus() (map[models.ProposalStatus]int, error) {
+	var rows []struct {
+		Status models.ProposalStatus
+		Count  int
+	}
+
+	err := r.db.Model((*models.Proposal)(nil)).
+		Column("status").
+		ColumnExpr("count(*) AS count").
+		Group("status").
+		Select(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[models.ProposalStatus]int, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+
+	return counts, nil
+}
+
+// GetManyWithOpenPoll returns the finished proposals whose polls were not closed yet.
+func (r *proposalRepository) GetManyWithOpenPoll() ([]*models.Proposal, error) {
+	proposals := make([]*models.Proposal, 0)
+
+	err := r.db.Model(&proposals).
+		Where("status <> ?", models.ProposalStatusCreated).
+		Where("poll_closed_at IS NULL").
+		Select()
+
+	return proposals, err
+}
+
+func (r *proposalRepository) MarkPollClosed(request *models.Proposal) error {
+	now := time.Now()
+	request.PollClosedAt = &now
+
+	_, err := r.db.Model(request).
+		Column("poll_closed_at").
+		WherePK().
+		Update()
+	return err
+}
+
+// saveNominee creates a nominee who is not a user yet, only the role and the backers of an existing user are changed,
+// so the state the bot keeps on the user is not overwritten.
+func saveNominee(tx *pg.Tx, nominee *models.User) error {
+	if nominee.ID == 0 {
+		_, err := tx.Model(nominee).Insert()
+		return err
+	}
+
+	_, err := tx.Model(nominee).
+		Column("role", "backers_id").
+		WherePK().
+		Update()
+	return err
+}
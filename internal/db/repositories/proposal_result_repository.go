@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"access_governance_system/internal/db/models"
+	"errors"
+
+	"github.com/go-pg/pg/v10"
+)
+
+type proposalResultRepository struct {
+	repository
+}
+
+type ProposalResultRepository interface {
+	GetOneByProposalID(proposalID int) (*models.ProposalResult, error)
+}
+
+func NewProposalResultRepository(db *pg.DB) ProposalResultRepository {
+	return &proposalResultRepository{
+		repository: repository{
+			db: db,
+		},
+	}
+}
+
+func (r *proposalResultRepository) GetOneByProposalID(proposalID int) (*models.ProposalResult, error) {
+	result := &models.ProposalResult{}
+
+	err := r.db.Model(result).
+		Where("proposal_id = ?", proposalID).
+		Select()
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return result, err
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"access_governance_system/internal/db/models"
+	"errors"
+
+	"github.com/go-pg/pg/v10"
+)
+
+type telegramPollRepository struct {
+	repository
+}
+
+type TelegramPollRepository interface {
+	Create(request *models.TelegramPoll) (*models.TelegramPoll, error)
+	Update(request *models.TelegramPoll) error
+	GetOneByID(id int) (*models.TelegramPoll, error)
+	GetOneByTelegramPollID(telegramPollID string) (*models.TelegramPoll, error)
+}
+
+func NewTelegramPollRepository(db *pg.DB) TelegramPollRepository {
+	return &telegramPollRepository{
+		repository: repository{
+			db: db,
+		},
+	}
+}
+
+func (r *telegramPollRepository) Create(request *models.TelegramPoll) (*models.TelegramPoll, error) {
+	_, err := r.db.Model(request).Insert()
+	if err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+func (r *telegramPollRepository) Update(request *models.TelegramPoll) error {
+	_, err := r.db.Model(request).WherePK().Update()
+	return err
+}
+
+func (r *telegramPollRepository) GetOneByID(id int) (*models.TelegramPoll, error) {
+	poll := &models.TelegramPoll{}
+
+	err := r.db.Model(poll).
+		Where("id = ?", id).
+		Select()
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return poll, err
+}
+
+func (r *telegramPollRepository) GetOneByTelegramPollID(telegramPollID string) (*models.TelegramPoll, error) {
+	poll := &models.TelegramPoll{}
+
+	err := r.db.Model(poll).
+		Where("telegram_poll_id = ?", telegramPollID).
+		Select()
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return poll, err
+}
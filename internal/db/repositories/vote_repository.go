@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"access_governance_system/internal/db/models"
+	"errors"
+
+	"github.com/go-pg/pg/v10"
+)
+
+type voteRepository struct {
+	repository
+}
+
+type VoteRepository interface {
+	Save(request *models.Vote) error
+	GetManyByPollID(source models.VoteSource, pollID int) ([]*models.Vote, error)
+}
+
+func NewVoteRepository(db *pg.DB) VoteRepository {
+	return &voteRepository{
+		repository: repository{
+			db: db,
+		},
+	}
+}
+
+// Save stores the vote unless a later vote of the same user in the poll of the source is already stored,
+// so the events may be delivered more than once and out of order.
+func (r *voteRepository) Save(request *models.Vote) error {
+	_, err := r.db.Model(request).
+		OnConflict("(source, poll_id, user_id) DO UPDATE").
+		Set("option = EXCLUDED.option").
+		Set("voted_at = EXCLUDED.voted_at").
+		Set("received_at = now()").
+		Where("vote.voted_at <= EXCLUDED.voted_at").
+		Insert()
+	if errors.Is(err, pg.ErrNoRows) {
+		// The stored vote is later, the update was skipped.
+		return nil
+	}
+	return err
+}
+
+// GetManyByPollID returns the votes of the poll of the source which are not retracted.
+func (r *voteRepository) GetManyByPollID(source models.VoteSource, pollID int) ([]*models.Vote, error) {
+	votes := make([]*models.Vote, 0)
+
+	err := r.db.Model(&votes).
+		Where("source = ?", source).
+		Where("poll_id = ?", pollID).
+		Where("option <> ''").
+		Order("user_id ASC").
+		Select()
+
+	return votes, err
+}
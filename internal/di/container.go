@@ -1,10 +1,41 @@
 package di
 
 import (
+	"os"
+
+	"access_governance_system/configs"
+	"access_governance_system/internal/db/repositories"
+	"access_governance_system/internal/services"
+	"github.com/go-pg/pg/v10"
 	prettyconsole "github.com/thessem/zap-prettyconsole"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 func NewLogger() *zap.SugaredLogger {
 	return prettyconsole.NewLogger(zap.DebugLevel).Sugar()
 }
+
+// NewStderrLogger is the same logger writing to stderr, for commands that print their own output to stdout.
+func NewStderrLogger() *zap.SugaredLogger {
+	encoder := prettyconsole.NewEncoder(prettyconsole.NewEncoderConfig())
+	return zap.New(zapcore.NewCore(encoder, os.Stderr, zap.DebugLevel)).Sugar()
+}
+
+// NewVoteService returns the vote service of the configured votes source,
+// the Telegram polls are posted to the seeders chat with the bot.
+func NewVoteService(database *pg.DB, config configs.VoteAPI, votes configs.Votes, bot configs.Bot, seedersChatID int64) services.VoteService {
+	switch votes.Source {
+	case configs.VotesSourceTelegram:
+		return services.NewTelegramVoteService(
+			bot.Token,
+			seedersChatID,
+			repositories.NewTelegramPollRepository(database),
+			repositories.NewVoteRepository(database),
+		)
+	case configs.VotesSourceWebhook:
+		return services.NewLocalVoteService(services.NewVoteService(config), repositories.NewVoteRepository(database))
+	default:
+		return services.NewVoteService(config)
+	}
+}
@@ -0,0 +1,403 @@
+// Package fakevoteapi is an in-memory implementation of the HTTP contract of the vote API,
+// so the bots and the proposal state service can run without the ultimate-poll-bot stack.
+package fakevoteapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/services"
+	"access_governance_system/internal/webhook"
+)
+
+const (
+	dueDateLayout  = time.RFC3339
+	webhookTimeout = 5 * time.Second
+)
+
+var (
+	ErrPollNotFound  = errors.New("poll not found")
+	ErrPollClosed    = errors.New("poll is closed")
+	ErrUnknownOption = errors.New("unknown option")
+)
+
+// Poll is a snapshot of a poll kept by the server.
+type Poll struct {
+	ID          int
+	Title       string
+	Description string
+	DueDate     time.Time
+	Options     []string
+	Closed      bool
+	Votes       []services.Vote
+}
+
+type poll struct {
+	Poll
+	votes map[int64]string
+}
+
+// Server serves POST /poll, GET /vote?poll_id=<id>, POST /poll/<id>/due_date and POST /poll/<id>/close
+// the way the vote API does, and POST /poll/<id>/vote with a vote as the body, so votes can be cast
+// without Telegram, an empty option retracts the vote. No vote is accepted after the due date.
+// The polls are reported as posted to chatID with the poll ID as the message ID.
+type Server struct {
+	mu     sync.Mutex
+	chatID int
+	nextID int
+	polls  map[int]*poll
+
+	client        *http.Client
+	webhookURL    string
+	webhookSecret string
+}
+
+func NewServer(chatID int) *Server {
+	return &Server{
+		chatID: chatID,
+		nextID: 1,
+		polls:  make(map[int]*poll),
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// NewTestServer starts the server on a local port, the caller closes the returned httptest server.
+func NewTestServer(chatID int) (*Server, *httptest.Server) {
+	server := NewServer(chatID)
+	return server, httptest.NewServer(server)
+}
+
+// SetWebhook makes the server post a signed event for every vote which is cast, changed or retracted.
+func (s *Server) SetWebhook(url, secret string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.webhookURL = url
+	s.webhookSecret = secret
+}
+
+// Vote casts or changes the vote of the user, as if they voted in Telegram, an empty option retracts it.
+func (s *Server) Vote(pollID int, userID int64, option string) error {
+	s.mu.Lock()
+
+	p, ok := s.polls[pollID]
+	if !ok {
+		s.mu.Unlock()
+		return ErrPollNotFound
+	} else if p.Closed || time.Now().After(p.DueDate) {
+		s.mu.Unlock()
+		return ErrPollClosed
+	} else if option != "" && !p.hasOption(option) {
+		s.mu.Unlock()
+		return fmt.Errorf("%w: %q", ErrUnknownOption, option)
+	}
+
+	p.setVote(userID, option)
+	s.mu.Unlock()
+
+	return s.deliver(newVoteEvent(pollID, userID, option))
+}
+
+// SetVotes replaces all the votes of the poll, the votes which are left out are retracted.
+func (s *Server) SetVotes(pollID int, votes []services.Vote) error {
+	s.mu.Lock()
+
+	p, ok := s.polls[pollID]
+	if !ok {
+		s.mu.Unlock()
+		return ErrPollNotFound
+	}
+
+	for _, vote := range votes {
+		if !p.hasOption(vote.Option) {
+			s.mu.Unlock()
+			return fmt.Errorf("%w: %q", ErrUnknownOption, vote.Option)
+		}
+	}
+
+	previousVotes := p.votes
+	p.votes = make(map[int64]string, len(votes))
+
+	events := make([]webhook.VoteEvent, 0, len(votes)+len(previousVotes))
+	for _, vote := range votes {
+		p.setVote(vote.UserID, vote.Option)
+		events = append(events, newVoteEvent(pollID, vote.UserID, vote.Option))
+	}
+	for userID := range previousVotes {
+		if _, ok := p.votes[userID]; !ok {
+			events = append(events, newVoteEvent(pollID, userID, ""))
+		}
+	}
+	s.mu.Unlock()
+
+	for _, event := range events {
+		if err := s.deliver(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Poll returns a snapshot of the poll with its votes.
+func (s *Server) Poll(pollID int) (Poll, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.polls[pollID]
+	if !ok {
+		return Poll{}, false
+	}
+
+	snapshot := p.Poll
+	snapshot.Votes = p.sortedVotes()
+	return snapshot, true
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	parts := strings.Split(path, "/")
+
+	switch {
+	case path == "" && r.Method == http.MethodGet:
+		w.WriteHeader(http.StatusOK)
+	case path == "poll" && r.Method == http.MethodPost:
+		s.createPoll(w, r)
+	case path == "vote" && r.Method == http.MethodGet:
+		s.getVotes(w, r)
+	case len(parts) == 3 && parts[0] == "poll" && parts[2] == "due_date" && r.Method == http.MethodPost:
+		s.setDueDate(w, r, parts[1])
+	case len(parts) == 3 && parts[0] == "poll" && parts[2] == "close" && r.Method == http.MethodPost:
+		s.closePoll(w, parts[1])
+	case len(parts) == 3 && parts[0] == "poll" && parts[2] == "vote" && r.Method == http.MethodPost:
+		s.vote(w, r, parts[1])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) createPoll(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Title       string   `json:"name"`
+		Description string   `json:"description"`
+		DueDate     string   `json:"due_date"`
+		Options     []string `json:"options"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dueDate, err := time.Parse(dueDateLayout, request.DueDate)
+	if err != nil {
+		http.Error(w, "due_date is invalid", http.StatusBadRequest)
+		return
+	} else if request.Title == "" || len(request.Options) == 0 {
+		http.Error(w, "name and options are required", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	p := &poll{
+		Poll: Poll{
+			ID:          s.nextID,
+			Title:       request.Title,
+			Description: request.Description,
+			DueDate:     dueDate,
+			Options:     request.Options,
+		},
+		votes: make(map[int64]string),
+	}
+	s.polls[p.ID] = p
+	s.nextID++
+	s.mu.Unlock()
+
+	writeJSON(w, models.Poll{
+		ID:            p.ID,
+		ChatID:        s.chatID,
+		PollMessageID: p.ID,
+	})
+}
+
+func (s *Server) getVotes(w http.ResponseWriter, r *http.Request) {
+	pollID, err := strconv.Atoi(r.URL.Query().Get("poll_id"))
+	if err != nil {
+		http.Error(w, "poll_id is invalid", http.StatusBadRequest)
+		return
+	}
+
+	snapshot, ok := s.Poll(pollID)
+	if !ok {
+		http.Error(w, ErrPollNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, snapshot.Votes)
+}
+
+func (s *Server) setDueDate(w http.ResponseWriter, r *http.Request, id string) {
+	pollID, err := strconv.Atoi(id)
+	if err != nil {
+		http.Error(w, "poll id is invalid", http.StatusBadRequest)
+		return
+	}
+
+	var request struct {
+		DueDate string `json:"due_date"`
+	}
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dueDate, err := time.Parse(dueDateLayout, request.DueDate)
+	if err != nil {
+		http.Error(w, "due_date is invalid", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.polls[pollID]
+	if !ok {
+		http.Error(w, ErrPollNotFound.Error(), http.StatusNotFound)
+		return
+	} else if p.Closed {
+		http.Error(w, ErrPollClosed.Error(), http.StatusBadRequest)
+		return
+	}
+
+	p.DueDate = dueDate
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) closePoll(w http.ResponseWriter, id string) {
+	pollID, err := strconv.Atoi(id)
+	if err != nil {
+		http.Error(w, "poll id is invalid", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.polls[pollID]
+	if !ok {
+		http.Error(w, ErrPollNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	p.Closed = true
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) vote(w http.ResponseWriter, r *http.Request, id string) {
+	pollID, err := strconv.Atoi(id)
+	if err != nil {
+		http.Error(w, "poll id is invalid", http.StatusBadRequest)
+		return
+	}
+
+	var vote services.Vote
+	if err = json.NewDecoder(r.Body).Decode(&vote); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = s.Vote(pollID, vote.UserID, vote.Option)
+	switch {
+	case errors.Is(err, ErrPollNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrPollClosed), errors.Is(err, ErrUnknownOption):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusBadGateway)
+	default:
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (p *poll) setVote(userID int64, option string) {
+	if option == "" {
+		delete(p.votes, userID)
+	} else {
+		p.votes[userID] = option
+	}
+}
+
+func newVoteEvent(pollID int, userID int64, option string) webhook.VoteEvent {
+	return webhook.VoteEvent{PollID: pollID, UserID: userID, Option: option, VotedAt: time.Now()}
+}
+
+// deliver posts the event to the webhook if there is one.
+func (s *Server) deliver(event webhook.VoteEvent) error {
+	s.mu.Lock()
+	url, secret := s.webhookURL, s.webhookSecret
+	s.mu.Unlock()
+
+	if url == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(webhook.SignatureHeader, webhook.Sign(secret, body))
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to deliver vote event: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("failed to deliver vote event: webhook responded with %d", response.StatusCode)
+	}
+	return nil
+}
+
+func (p *poll) hasOption(option string) bool {
+	for _, o := range p.Options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedVotes orders the votes by user, so the answers do not depend on the map order.
+func (p *poll) sortedVotes() []services.Vote {
+	votes := make([]services.Vote, 0, len(p.votes))
+	for userID, option := range p.votes {
+		votes = append(votes, services.Vote{UserID: userID, Option: option})
+	}
+
+	sort.Slice(votes, func(i, j int) bool {
+		return votes[i].UserID < votes[j].UserID
+	})
+	return votes
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,83 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	tgbot "access_governance_system/internal/tg_bot/extension"
+
+	"github.com/go-co-op/gocron"
+	"github.com/go-pg/pg/v10"
+)
+
+const missedTickTolerance = time.Minute
+
+// DBCheck pings Postgres.
+func DBCheck(db *pg.DB) Check {
+	return func(ctx context.Context) (interface{}, error) {
+		return nil, db.Ping(ctx)
+	}
+}
+
+// VoteAPICheck requests the vote API, any answer but a server error means it is up.
+func VoteAPICheck(url string) Check {
+	return func(ctx context.Context) (interface{}, error) {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			return nil, err
+		}
+		defer response.Body.Close()
+
+		if response.StatusCode >= http.StatusInternalServerError {
+			return nil, fmt.Errorf("vote api responded with %d", response.StatusCode)
+		}
+		return nil, nil
+	}
+}
+
+// TelegramCheck calls getMe with the bot token.
+func TelegramCheck(token string) Check {
+	return func(ctx context.Context) (interface{}, error) {
+		bot, err := tgbot.NewBotAPIWithContext(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"username": bot.Self.UserName}, nil
+	}
+}
+
+type schedulerDetails struct {
+	LastTick *time.Time `json:"last_tick"`
+	NextTick time.Time  `json:"next_tick"`
+	Running  bool       `json:"running"`
+}
+
+// SchedulerCheck reports the last and the next tick of the job and fails if the scheduler is stopped
+// or the job has missed its tick.
+func SchedulerCheck(scheduler *gocron.Scheduler, job *gocron.Job) Check {
+	return func(ctx context.Context) (interface{}, error) {
+		details := schedulerDetails{
+			NextTick: job.NextRun(),
+			Running:  job.IsRunning(),
+		}
+		if lastTick := job.LastRun(); !lastTick.IsZero() {
+			details.LastTick = &lastTick
+		}
+
+		if !scheduler.IsRunning() {
+			return details, errors.New("scheduler is not running")
+		}
+		if !details.Running && details.NextTick.Before(time.Now().Add(-missedTickTolerance)) {
+			return details, errors.New("scheduler missed a tick")
+		}
+		return details, nil
+	}
+}
@@ -0,0 +1,135 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+const (
+	checkTimeout    = 5 * time.Second
+	shutdownTimeout = 5 * time.Second
+
+	statusOK    = "ok"
+	statusError = "error"
+)
+
+// Check reports whether a dependency is usable, details are shown in the readiness response as they are.
+type Check func(ctx context.Context) (details interface{}, err error)
+
+type checkResult struct {
+	Status  string      `json:"status"`
+	Error   string      `json:"error,omitempty"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+type readinessResponse struct {
+	Status string                 `json:"status"`
+	Checks map[string]checkResult `json:"checks"`
+}
+
+type Server interface {
+	AddCheck(name string, check Check)
+	Handle(pattern string, handler http.Handler)
+	Start()
+	Shutdown()
+}
+
+type server struct {
+	server *http.Server
+	mux    *http.ServeMux
+	checks map[string]Check
+	logger *zap.SugaredLogger
+}
+
+// NewServer serves the liveness at /<service>/healthcheck, which always answers while the process is up,
+// and the readiness at /<service>/readiness, which runs the checks and answers 503 if any of them fails.
+// The Prometheus metrics are served at /metrics.
+func NewServer(service, addr string, logger *zap.SugaredLogger) Server {
+	s := &server{
+		checks: make(map[string]Check),
+		logger: logger,
+	}
+
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc(fmt.Sprintf("/%s/healthcheck", service), s.livenessHandler)
+	s.mux.HandleFunc(fmt.Sprintf("/%s/readiness", service), s.readinessHandler)
+	s.mux.Handle("/metrics", promhttp.Handler())
+
+	s.server = &http.Server{Addr: addr, Handler: s.mux}
+
+	return s
+}
+
+// AddCheck must be called before Start.
+func (s *server) AddCheck(name string, check Check) {
+	s.checks[name] = check
+}
+
+// Handle serves another endpoint on the same port, it must be called before Start too.
+func (s *server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+func (s *server) Start() {
+	go func() {
+		if err := s.server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Errorw("failed to start http server", "error", err)
+		}
+	}()
+}
+
+func (s *server) Shutdown() {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := s.server.Shutdown(ctx); err != nil {
+		s.logger.Errorw("failed to shutdown http server", "error", err)
+	}
+}
+
+func (s *server) livenessHandler(w http.ResponseWriter, r *http.Request) {
+	_, _ = w.Write([]byte("I'm alive"))
+}
+
+func (s *server) readinessHandler(w http.ResponseWriter, r *http.Request) {
+	response := readinessResponse{
+		Status: statusOK,
+		Checks: make(map[string]checkResult, len(s.checks)),
+	}
+
+	names := make([]string, 0, len(s.checks))
+	for name := range s.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+		details, err := s.checks[name](ctx)
+		cancel()
+
+		result := checkResult{Status: statusOK, Details: details}
+		if err != nil {
+			result.Status = statusError
+			result.Error = err.Error()
+			response.Status = statusError
+			s.logger.Warnw("readiness check failed", "check", name, "error", err)
+		}
+		response.Checks[name] = result
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if response.Status != statusOK {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	_ = json.NewEncoder(w).Encode(response)
+}
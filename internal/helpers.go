@@ -4,8 +4,16 @@ import "time"
 
 const (
 	formatDDMMYYYY = "02.01.2006"
+
+	votingDeadlineHour = 12
 )
 
 func Format(date time.Time) string {
 	return date.Format(formatDDMMYYYY)
 }
+
+// VotingDeadline returns noon of the day the given number of days after from, in the community location.
+func VotingDeadline(from time.Time, days int, location *time.Location) time.Time {
+	date := from.In(location).AddDate(0, 0, days)
+	return time.Date(date.Year(), date.Month(), date.Day(), votingDeadlineHour, 0, 0, 0, location)
+}
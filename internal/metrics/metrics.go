@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"time"
+
+	"access_governance_system/internal/db/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	namespace = "access_governance"
+
+	resultSuccess = "success"
+	resultError   = "error"
+)
+
+var (
+	commandsHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "telegram_commands_handled_total",
+		Help:      "Telegram updates handled, by the command which handled them.",
+	}, []string{"command"})
+
+	commandDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "telegram_command_duration_seconds",
+		Help:      "How long a command took to handle a Telegram update.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"command"})
+
+	telegramSendFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "telegram_send_failures_total",
+		Help:      "Messages Telegram failed to send.",
+	})
+
+	proposals = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "proposals",
+		Help:      "Proposals by status.",
+	}, []string{"status"})
+
+	voteAPIDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "vote_api_request_duration_seconds",
+		Help:      "How long a vote API call took, by operation and result.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation", "result"})
+
+	voteAPIErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "vote_api_errors_total",
+		Help:      "Failed vote API calls, by operation.",
+	}, []string{"operation"})
+
+	jobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "job_duration_seconds",
+		Help:      "How long a scheduled job run took.",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300},
+	}, []string{"job"})
+
+	jobRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "job_runs_total",
+		Help:      "Scheduled job runs, by job and status.",
+	}, []string{"job", "status"})
+
+	outboxMessages = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "outbox_messages",
+		Help:      "Outbox messages which are pending or failed for good.",
+	}, []string{"status"})
+)
+
+// ObserveCommand is meant to be deferred with the time the command started handling the update.
+func ObserveCommand(command string, started time.Time) {
+	commandsHandled.WithLabelValues(command).Inc()
+	commandDuration.WithLabelValues(command).Observe(time.Since(started).Seconds())
+}
+
+func TelegramSendFailed() {
+	telegramSendFailures.Inc()
+}
+
+func SetProposals(counts map[models.ProposalStatus]int) {
+	for _, status := range []models.ProposalStatus{
+		models.ProposalStatusCreated,
+		models.ProposalStatusApproved,
+		models.ProposalStatusRejected,
+		models.ProposalStatusNoQuorum,
+		models.ProposalStatusWithdrawn,
+	} {
+		proposals.WithLabelValues(status.String()).Set(float64(counts[status]))
+	}
+}
+
+func ObserveVoteAPICall(operation string, started time.Time, err error) {
+	result := resultSuccess
+	if err != nil {
+		result = resultError
+		voteAPIErrors.WithLabelValues(operation).Inc()
+	}
+	voteAPIDuration.WithLabelValues(operation, result).Observe(time.Since(started).Seconds())
+}
+
+func ObserveJobRun(run *models.JobRun) {
+	jobDuration.WithLabelValues(run.Name).Observe(run.FinishedAt.Sub(run.StartedAt).Seconds())
+	jobRuns.WithLabelValues(run.Name, run.Status.String()).Inc()
+}
+
+func SetOutboxMessages(pending, failed int) {
+	outboxMessages.WithLabelValues(models.OutboxMessageStatusPending.String()).Set(float64(pending))
+	outboxMessages.WithLabelValues(models.OutboxMessageStatusFailed.String()).Set(float64(failed))
+}
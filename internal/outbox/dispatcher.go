@@ -0,0 +1,181 @@
+package outbox
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"access_governance_system/configs"
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/db/repositories"
+	"access_governance_system/internal/metrics"
+	tgbot "access_governance_system/internal/tg_bot/extension"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+const dispatchBatchSize = 50
+
+// Stats are the counts of the messages which are still to be delivered and which were given up on.
+type Stats struct {
+	Pending int `json:"pending"`
+	Failed  int `json:"failed"`
+}
+
+type Dispatcher interface {
+	Dispatch()
+	Stats() (Stats, error)
+}
+
+type dispatcher struct {
+	outboxMessageRepository repositories.OutboxMessageRepository
+	newBot                  func() (*tgbotapi.BotAPI, error)
+	config                  configs.Outbox
+	logger                  *zap.SugaredLogger
+}
+
+func NewDispatcher(
+	outboxMessageRepository repositories.OutboxMessageRepository,
+	botToken string,
+	config configs.Outbox,
+	logger *zap.SugaredLogger,
+) Dispatcher {
+	return &dispatcher{
+		outboxMessageRepository: outboxMessageRepository,
+		newBot: func() (*tgbotapi.BotAPI, error) {
+			return tgbotapi.NewBotAPI(botToken)
+		},
+		config: config,
+		logger: logger,
+	}
+}
+
+// Dispatch delivers the due messages. A failed message is retried with an exponential backoff
+// and dead-lettered as failed once it runs out of attempts or Telegram refuses it for good.
+func (d *dispatcher) Dispatch() {
+	messages, err := d.outboxMessageRepository.GetManyDue(dispatchBatchSize)
+	if err != nil {
+		d.logger.Errorw("failed to get outbox messages", "error", err)
+		return
+	}
+
+	if len(messages) > 0 {
+		bot, err := d.newBot()
+		if err != nil {
+			d.logger.Errorw("could not create bot", "error", err)
+			return
+		}
+
+		for _, message := range messages {
+			d.deliver(bot, message)
+		}
+	}
+
+	stats, err := d.Stats()
+	if err != nil {
+		d.logger.Errorw("failed to count outbox messages", "error", err)
+		return
+	}
+
+	metrics.SetOutboxMessages(stats.Pending, stats.Failed)
+
+	if stats.Pending > 0 || stats.Failed > 0 {
+		d.logger.Infow("outbox messages", "pending", stats.Pending, "failed", stats.Failed)
+	}
+}
+
+func (d *dispatcher) Stats() (Stats, error) {
+	pending, err := d.outboxMessageRepository.CountByStatus(models.OutboxMessageStatusPending)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	failed, err := d.outboxMessageRepository.CountByStatus(models.OutboxMessageStatusFailed)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	return Stats{Pending: pending, Failed: failed}, nil
+}
+
+func (d *dispatcher) deliver(bot *tgbotapi.BotAPI, message *models.OutboxMessage) {
+	if message.InviteLink != nil {
+		inviteLink, err := tgbot.CreateChatInviteLink(
+			bot,
+			message.InviteLink.ChatID,
+			message.InviteLink.NominatorTelegramNickname,
+			message.InviteLink.NomineeTelegramNickname,
+		)
+		if err != nil {
+			d.fail(message, err)
+			return
+		}
+
+		// The link is saved before sending, so a retry does not create another one.
+		message.Text = strings.ReplaceAll(message.Text, models.OutboxInviteLinkPlaceholder, inviteLink)
+		message.InviteLink = nil
+		if err = d.outboxMessageRepository.Update(message); err != nil {
+			d.logger.Errorw("failed to update outbox message", "error", err, "id", message.ID)
+			return
+		}
+	}
+
+	if _, err := bot.Send(messageConfig(message)); err != nil {
+		metrics.TelegramSendFailed()
+		d.fail(message, err)
+		return
+	}
+
+	message.Status = models.OutboxMessageStatusSent
+	message.SentAt = time.Now()
+	message.LastError = ""
+	if err := d.outboxMessageRepository.Update(message); err != nil {
+		d.logger.Errorw("failed to update outbox message", "error", err, "id", message.ID)
+	}
+}
+
+func (d *dispatcher) fail(message *models.OutboxMessage, sendErr error) {
+	message.Attempts++
+	message.LastError = sendErr.Error()
+
+	if isPermanent(sendErr) || message.Attempts >= d.config.MaxAttempts {
+		message.Status = models.OutboxMessageStatusFailed
+		d.logger.Errorw("outbox message is dead-lettered", "error", sendErr, "id", message.ID, "attempts", message.Attempts)
+	} else {
+		message.NextAttemptAt = time.Now().Add(d.retryDelay(message.Attempts, sendErr))
+		d.logger.Warnw("failed to send outbox message", "error", sendErr, "id", message.ID, "attempts", message.Attempts)
+	}
+
+	if err := d.outboxMessageRepository.Update(message); err != nil {
+		d.logger.Errorw("failed to update outbox message", "error", err, "id", message.ID)
+	}
+}
+
+// retryDelay doubles the delay after every attempt, but waits as long as Telegram asks to when rate limited.
+func (d *dispatcher) retryDelay(attempts int, sendErr error) time.Duration {
+	var telegramErr *tgbotapi.Error
+	if errors.As(sendErr, &telegramErr) && telegramErr.RetryAfter > 0 {
+		return time.Duration(telegramErr.RetryAfter) * time.Second
+	}
+
+	delay := d.config.RetryDelay
+	for i := 1; i < attempts && delay < d.config.MaxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > d.config.MaxRetryDelay {
+		delay = d.config.MaxRetryDelay
+	}
+	return delay
+}
+
+// isPermanent reports whether Telegram refused the message in a way a retry does not fix,
+// for example the user blocked the bot or the chat does not exist.
+func isPermanent(err error) bool {
+	var telegramErr *tgbotapi.Error
+	if !errors.As(err, &telegramErr) {
+		return false
+	}
+	return telegramErr.Code == http.StatusBadRequest || telegramErr.Code == http.StatusForbidden
+}
@@ -0,0 +1,113 @@
+package outbox
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"access_governance_system/configs"
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/db/repositories/mocks"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+var testConfig = configs.Outbox{
+	MaxAttempts:   10,
+	RetryDelay:    time.Minute,
+	MaxRetryDelay: time.Hour,
+}
+
+// newFakeTelegram answers sendMessage with the response scripted for the chat the message is sent to.
+func newFakeTelegram(t *testing.T, responses map[string]string) func() (*tgbotapi.BotAPI, error) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/getMe") {
+			_, _ = w.Write([]byte(`{"ok": true, "result": {"id": 1, "is_bot": true, "first_name": "bot", "username": "bot"}}`))
+			return
+		}
+
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("failed to parse request: %v", err)
+		}
+		_, _ = w.Write([]byte(responses[r.Form.Get("chat_id")]))
+	}))
+	t.Cleanup(server.Close)
+
+	return func() (*tgbotapi.BotAPI, error) {
+		return tgbotapi.NewBotAPIWithClient("token", server.URL+"/bot%s/%s", server.Client())
+	}
+}
+
+func TestDispatcherDispatch(t *testing.T) {
+	const (
+		sent        = `{"ok": true, "result": {"message_id": 1, "chat": {"id": 1}}}`
+		serverError = `{"ok": false, "error_code": 500, "description": "Internal Server Error"}`
+		rateLimited = `{"ok": false, "error_code": 429, "description": "Too Many Requests", "parameters": {"retry_after": 30}}`
+		blocked     = `{"ok": false, "error_code": 403, "description": "Forbidden: bot was blocked by the user"}`
+		badRequest  = `{"ok": false, "error_code": 400, "description": "Bad Request: chat not found"}`
+	)
+
+	tests := []struct {
+		name         string
+		response     string
+		attempts     int
+		wantStatus   models.OutboxMessageStatus
+		wantAttempts int
+		wantDelay    time.Duration // until the next attempt of a pending message
+	}{
+		{name: "sent", response: sent, wantStatus: models.OutboxMessageStatusSent},
+		{name: "first retry", response: serverError, wantStatus: models.OutboxMessageStatusPending, wantAttempts: 1, wantDelay: time.Minute},
+		{name: "delay doubles", response: serverError, attempts: 2, wantStatus: models.OutboxMessageStatusPending, wantAttempts: 3, wantDelay: 4 * time.Minute},
+		{name: "delay is capped", response: serverError, attempts: 7, wantStatus: models.OutboxMessageStatusPending, wantAttempts: 8, wantDelay: time.Hour},
+		{name: "retry after", response: rateLimited, attempts: 3, wantStatus: models.OutboxMessageStatusPending, wantAttempts: 4, wantDelay: 30 * time.Second},
+		{name: "out of attempts", response: serverError, attempts: 9, wantStatus: models.OutboxMessageStatusFailed, wantAttempts: 10},
+		{name: "blocked by the user", response: blocked, wantStatus: models.OutboxMessageStatusFailed, wantAttempts: 1},
+		{name: "bad request", response: badRequest, wantStatus: models.OutboxMessageStatusFailed, wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			outboxMessageRepository := mock_repositories.NewMockOutboxMessageRepository(ctrl)
+
+			message := &models.OutboxMessage{ID: 1, ChatID: 42, Text: "text", Status: models.OutboxMessageStatusPending, Attempts: tt.attempts}
+
+			outboxMessageRepository.EXPECT().GetManyDue(dispatchBatchSize).Return([]*models.OutboxMessage{message}, nil)
+			outboxMessageRepository.EXPECT().Update(message).Return(nil)
+			outboxMessageRepository.EXPECT().CountByStatus(gomock.Any()).Return(0, nil).AnyTimes()
+
+			d := &dispatcher{
+				outboxMessageRepository: outboxMessageRepository,
+				newBot:                  newFakeTelegram(t, map[string]string{"42": tt.response}),
+				config:                  testConfig,
+				logger:                  zap.NewNop().Sugar(),
+			}
+
+			startedAt := time.Now()
+			d.Dispatch()
+
+			if message.Status != tt.wantStatus || message.Attempts != tt.wantAttempts {
+				t.Errorf("message is %s after %d attempts, want %s after %d", message.Status, message.Attempts, tt.wantStatus, tt.wantAttempts)
+			}
+
+			if tt.wantStatus == models.OutboxMessageStatusPending {
+				delay := message.NextAttemptAt.Sub(startedAt)
+				if delay < tt.wantDelay || delay > tt.wantDelay+time.Minute {
+					t.Errorf("next attempt in %s, want %s", delay, tt.wantDelay)
+				}
+			}
+
+			if tt.wantStatus == models.OutboxMessageStatusSent && (message.SentAt.IsZero() || message.LastError != "") {
+				t.Errorf("sent message has sent at %s and last error %q", message.SentAt, message.LastError)
+			}
+			if tt.wantStatus != models.OutboxMessageStatusSent && message.LastError == "" {
+				t.Error("failed message has no last error")
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package outbox
+
+import (
+	"encoding/json"
+
+	"access_governance_system/internal/db/models"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// NewMessage turns a message to send into an outbox message, only the fields the services set are kept.
+func NewMessage(message tgbotapi.MessageConfig) *models.OutboxMessage {
+	return &models.OutboxMessage{
+		ChatID:                message.ChatID,
+		Text:                  message.Text,
+		ParseMode:             message.ParseMode,
+		ReplyToMessageID:      message.ReplyToMessageID,
+		DisableWebPagePreview: message.DisableWebPagePreview,
+		ReplyMarkup:           replyMarkup(message.ReplyMarkup),
+		Status:                models.OutboxMessageStatusPending,
+	}
+}
+
+// NewMessageWithInviteLink is NewMessage for a text with models.OutboxInviteLinkPlaceholder
+// which is replaced by the invite link right before the message is sent.
+func NewMessageWithInviteLink(message tgbotapi.MessageConfig, inviteLink models.OutboxInviteLink) *models.OutboxMessage {
+	outboxMessage := NewMessage(message)
+	outboxMessage.InviteLink = &inviteLink
+	return outboxMessage
+}
+
+func messageConfig(message *models.OutboxMessage) tgbotapi.MessageConfig {
+	config := tgbotapi.NewMessage(message.ChatID, message.Text)
+	config.ParseMode = message.ParseMode
+	config.ReplyToMessageID = message.ReplyToMessageID
+	config.DisableWebPagePreview = message.DisableWebPagePreview
+	if message.ReplyMarkup != nil {
+		config.ReplyMarkup = message.ReplyMarkup
+	}
+	return config
+}
+
+// replyMarkup keeps the markup as plain JSON, Telegram gets it back marshalled the same way.
+func replyMarkup(markup interface{}) map[string]interface{} {
+	if markup == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(markup)
+	if err != nil {
+		return nil
+	}
+
+	var result map[string]interface{}
+	if err = json.Unmarshal(data, &result); err != nil {
+		return nil
+	}
+
+	return result
+}
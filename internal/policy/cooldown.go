@@ -0,0 +1,19 @@
+package policy
+
+import (
+	"time"
+
+	"access_governance_system/internal/db/models"
+)
+
+// rejectionCooldownMonths is how long the nominee of a rejected proposal cannot be proposed again.
+const rejectionCooldownMonths = 3
+
+// CooldownEnd returns when the nominee of the proposal can be proposed again,
+// only a rejection starts the cooldown, it is counted from the day the proposal was created.
+func CooldownEnd(proposal *models.Proposal) (time.Time, bool) {
+	if proposal.Status != models.ProposalStatusRejected {
+		return time.Time{}, false
+	}
+	return proposal.CreatedAt.AddDate(0, rejectionCooldownMonths, 0), true
+}
@@ -0,0 +1,33 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"access_governance_system/internal/db/models"
+)
+
+func TestCooldownEnd(t *testing.T) {
+	createdAt := time.Date(2026, time.January, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		status models.ProposalStatus
+		want   time.Time
+		wantOK bool
+	}{
+		{status: models.ProposalStatusRejected, want: time.Date(2026, time.April, 15, 12, 0, 0, 0, time.UTC), wantOK: true},
+		{status: models.ProposalStatusCreated},
+		{status: models.ProposalStatusApproved},
+		{status: models.ProposalStatusNoQuorum},
+		{status: models.ProposalStatusWithdrawn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			got, ok := CooldownEnd(&models.Proposal{Status: tt.status, CreatedAt: createdAt})
+			if !got.Equal(tt.want) || ok != tt.wantOK {
+				t.Errorf("CooldownEnd() = %s, %t, want %s, %t", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
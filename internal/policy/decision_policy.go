@@ -0,0 +1,111 @@
+package policy
+
+import (
+	"math"
+
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/services"
+)
+
+// Tally is an aggregated snapshot of the votes for a single proposal.
+type Tally struct {
+	YesVotes          int `json:"yes_votes"`
+	NoVotes           int `json:"no_votes"`
+	AbstainVotes      int `json:"abstain_votes"`
+	BlockVotes        int `json:"block_votes"`
+	VotedSeedersCount int `json:"voted_seeders_count"`
+	TotalSeedersCount int `json:"total_seeders_count"`
+}
+
+// Explanation describes the thresholds a tally was checked against and which of them were met.
+type Explanation struct {
+	MinRequiredSeedersCount       int  `json:"min_required_seeders_count"`
+	MinRequiredYesVotes           int  `json:"min_required_yes_votes"`
+	MinRequiredYesVotesToOverride int  `json:"min_required_yes_votes_to_override"`
+	QuorumReached                 bool `json:"quorum_reached"`
+	EnoughYesVotes                bool `json:"enough_yes_votes"`
+	OverriddenByNoVotes           bool `json:"overridden_by_no_votes"`
+	Blocked                       bool `json:"blocked"`
+}
+
+// Decision is the status of a proposal and the main reason it was given,
+// when several thresholds are missed the reason is the first of
+// blocked, no quorum, insufficient "yes" votes and overridden by "no" votes.
+type Decision struct {
+	Status       models.ProposalStatus `json:"status"`
+	Reason       models.DecisionReason `json:"reason"`
+	Explanation  Explanation           `json:"explanation"`
+	DecidedEarly bool                  `json:"decided_early"`
+}
+
+type DecisionPolicy interface {
+	Decide(tally Tally) Decision
+}
+
+type defaultPolicy struct {
+	rules models.VotingRules
+}
+
+// NewDefaultPolicy returns the quorum / minimum "yes" / override policy.
+func NewDefaultPolicy(rules models.VotingRules) DecisionPolicy {
+	return &defaultPolicy{rules: rules}
+}
+
+// Decide counts abstained seeders toward the quorum, but not toward the "yes" percentage.
+// Enough blocks reject a proposal no matter how the others voted.
+func (p *defaultPolicy) Decide(tally Tally) Decision {
+	explanation := Explanation{
+		MinRequiredSeedersCount:       p.minRequiredSeedersCount(tally.TotalSeedersCount),
+		MinRequiredYesVotes:           p.minRequiredYesVotes(tally.YesVotes + tally.NoVotes),
+		MinRequiredYesVotesToOverride: p.minRequiredYesVotesToOverride(tally.TotalSeedersCount),
+	}
+
+	explanation.QuorumReached = tally.VotedSeedersCount >= explanation.MinRequiredSeedersCount
+	explanation.EnoughYesVotes = tally.YesVotes >= explanation.MinRequiredYesVotes
+	explanation.OverriddenByNoVotes = tally.NoVotes > 0 && tally.YesVotes < explanation.MinRequiredYesVotesToOverride
+	explanation.Blocked = p.rules.BlocksToReject > 0 && tally.BlockVotes >= p.rules.BlocksToReject
+
+	decision := Decision{Explanation: explanation}
+
+	if explanation.Blocked {
+		decision.Status, decision.Reason = models.ProposalStatusRejected, models.DecisionReasonBlocked
+	} else if !explanation.QuorumReached {
+		decision.Status, decision.Reason = models.ProposalStatusNoQuorum, models.DecisionReasonNoQuorum
+	} else if !explanation.EnoughYesVotes {
+		decision.Status, decision.Reason = models.ProposalStatusRejected, models.DecisionReasonInsufficientYesVotes
+	} else if explanation.OverriddenByNoVotes {
+		decision.Status, decision.Reason = models.ProposalStatusRejected, models.DecisionReasonOverriddenByNoVotes
+	} else {
+		decision.Status, decision.Reason = models.ProposalStatusApproved, models.DecisionReasonApproved
+	}
+
+	return decision
+}
+
+func (p *defaultPolicy) minRequiredSeedersCount(totalSeeders int) int {
+	return int(math.Min(math.Round(float64(totalSeeders)*p.rules.Quorum), p.rules.MaxRequiredSeedersCount))
+}
+
+func (p *defaultPolicy) minRequiredYesVotes(votes int) int {
+	return int(math.Max(math.Round(float64(votes)*p.rules.MinYesVotesPercentage), p.rules.MinRequiredYesVotes))
+}
+
+func (p *defaultPolicy) minRequiredYesVotesToOverride(totalSeeders int) int {
+	return int(math.Round(float64(totalSeeders) * p.rules.YesVotesToOvercomeNo))
+}
+
+// CountVotes fills the yes/no/abstain part of a tally, the seeders counts are left to the caller.
+func CountVotes(votes []services.Vote) Tally {
+	var tally Tally
+	for _, vote := range votes {
+		switch vote.Option {
+		case services.VoteOptionYes:
+			tally.YesVotes++
+		case services.VoteOptionNo:
+			tally.NoVotes++
+		case services.VoteOptionAbstain:
+			tally.AbstainVotes++
+		}
+	}
+	return tally
+}
@@ -0,0 +1,148 @@
+package policy
+
+import (
+	"testing"
+
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/services"
+)
+
+var testRules = models.VotingRules{
+	Quorum:                  0.5,
+	MaxRequiredSeedersCount: 10,
+	MinYesVotesPercentage:   0.5,
+	MinRequiredYesVotes:     2,
+	YesVotesToOvercomeNo:    0.5,
+}
+
+func TestDefaultPolicyDecide(t *testing.T) {
+	rulesWithBlocks := testRules
+	rulesWithBlocks.BlocksToReject = 1
+
+	tests := []struct {
+		name       string
+		rules      models.VotingRules
+		tally      Tally
+		wantStatus models.ProposalStatus
+	}{
+		{
+			name:       "approved",
+			rules:      testRules,
+			tally:      Tally{YesVotes: 5, VotedSeedersCount: 5, TotalSeedersCount: 10},
+			wantStatus: models.ProposalStatusApproved,
+		},
+		{
+			name:       "abstained seeders count toward quorum",
+			rules:      testRules,
+			tally:      Tally{YesVotes: 3, AbstainVotes: 2, VotedSeedersCount: 5, TotalSeedersCount: 10},
+			wantStatus: models.ProposalStatusApproved,
+		},
+		{
+			name:       "abstained seeders do not count toward yes votes",
+			rules:      testRules,
+			tally:      Tally{YesVotes: 1, AbstainVotes: 1, VotedSeedersCount: 2, TotalSeedersCount: 4},
+			wantStatus: models.ProposalStatusRejected,
+		},
+		{
+			name:       "blocked",
+			rules:      rulesWithBlocks,
+			tally:      Tally{YesVotes: 5, BlockVotes: 1, VotedSeedersCount: 5, TotalSeedersCount: 10},
+			wantStatus: models.ProposalStatusRejected,
+		},
+		{
+			name:       "blocked without quorum",
+			rules:      rulesWithBlocks,
+			tally:      Tally{BlockVotes: 1, TotalSeedersCount: 10},
+			wantStatus: models.ProposalStatusRejected,
+		},
+		{
+			name:       "blocks disabled",
+			rules:      testRules,
+			tally:      Tally{YesVotes: 5, BlockVotes: 3, VotedSeedersCount: 5, TotalSeedersCount: 10},
+			wantStatus: models.ProposalStatusApproved,
+		},
+		{
+			name:       "no quorum",
+			rules:      testRules,
+			tally:      Tally{YesVotes: 4, VotedSeedersCount: 4, TotalSeedersCount: 10},
+			wantStatus: models.ProposalStatusNoQuorum,
+		},
+		{
+			name:       "quorum is capped by the max required seeders count",
+			rules:      testRules,
+			tally:      Tally{YesVotes: 10, VotedSeedersCount: 10, TotalSeedersCount: 40},
+			wantStatus: models.ProposalStatusApproved,
+		},
+		{
+			name:       "insufficient yes votes",
+			rules:      testRules,
+			tally:      Tally{YesVotes: 2, NoVotes: 4, VotedSeedersCount: 6, TotalSeedersCount: 10},
+			wantStatus: models.ProposalStatusRejected,
+		},
+		{
+			name:       "min required yes votes",
+			rules:      testRules,
+			tally:      Tally{YesVotes: 1, VotedSeedersCount: 2, TotalSeedersCount: 4},
+			wantStatus: models.ProposalStatusRejected,
+		},
+		{
+			name:       "overridden by no votes",
+			rules:      testRules,
+			tally:      Tally{YesVotes: 4, NoVotes: 1, VotedSeedersCount: 5, TotalSeedersCount: 10},
+			wantStatus: models.ProposalStatusRejected,
+		},
+		{
+			name:       "no votes overcome",
+			rules:      testRules,
+			tally:      Tally{YesVotes: 5, NoVotes: 1, VotedSeedersCount: 6, TotalSeedersCount: 10},
+			wantStatus: models.ProposalStatusApproved,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if decision := NewDefaultPolicy(tt.rules).Decide(tt.tally); decision.Status != tt.wantStatus {
+				t.Errorf("Decide() = %s, want %s", decision.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestCountVotes(t *testing.T) {
+	tests := []struct {
+		name  string
+		votes []services.Vote
+		want  Tally
+	}{
+		{
+			name: "no votes",
+			want: Tally{},
+		},
+		{
+			name: "every option",
+			votes: []services.Vote{
+				{UserID: 1, Option: services.VoteOptionYes},
+				{UserID: 2, Option: services.VoteOptionYes},
+				{UserID: 3, Option: services.VoteOptionNo},
+				{UserID: 4, Option: services.VoteOptionAbstain},
+			},
+			want: Tally{YesVotes: 2, NoVotes: 1, AbstainVotes: 1},
+		},
+		{
+			name: "unknown options are ignored",
+			votes: []services.Vote{
+				{UserID: 1, Option: services.VoteOptionAbstain},
+				{UserID: 2, Option: "maybe"},
+			},
+			want: Tally{AbstainVotes: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CountVotes(tt.votes); got != tt.want {
+				t.Errorf("CountVotes() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
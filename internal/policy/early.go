@@ -0,0 +1,45 @@
+package policy
+
+import "access_governance_system/internal/db/models"
+
+// DecideEarly reports whether the outcome of a proposal can no longer change,
+// no matter how the seeders who have not voted yet vote, if they vote at all,
+// and whether they block the proposal before the deadline. The seeders who have voted
+// are not expected to block it, so once everybody has voted only the actual blocks count.
+// A missed quorum is never decided early, there is always a chance it will be reached.
+func DecideEarly(decisionPolicy DecisionPolicy, tally Tally) (Decision, bool) {
+	decision := decisionPolicy.Decide(tally)
+	if decision.Status == models.ProposalStatusNoQuorum {
+		return decision, false
+	}
+
+	notVotedSeedersCount := tally.TotalSeedersCount - tally.VotedSeedersCount
+	if notVotedSeedersCount < 0 {
+		notVotedSeedersCount = 0
+	}
+
+	possibleTally := tally
+	possibleTally.BlockVotes += notVotedSeedersCount
+	if decisionPolicy.Decide(possibleTally).Status != decision.Status {
+		return decision, false
+	}
+
+	for yesVotes := 0; yesVotes <= notVotedSeedersCount; yesVotes++ {
+		for noVotes := 0; yesVotes+noVotes <= notVotedSeedersCount; noVotes++ {
+			for abstainVotes := 0; yesVotes+noVotes+abstainVotes <= notVotedSeedersCount; abstainVotes++ {
+				possibleTally := tally
+				possibleTally.YesVotes += yesVotes
+				possibleTally.NoVotes += noVotes
+				possibleTally.AbstainVotes += abstainVotes
+				possibleTally.VotedSeedersCount += yesVotes + noVotes + abstainVotes
+
+				if decisionPolicy.Decide(possibleTally).Status != decision.Status {
+					return decision, false
+				}
+			}
+		}
+	}
+
+	decision.DecidedEarly = true
+	return decision, true
+}
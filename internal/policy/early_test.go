@@ -0,0 +1,100 @@
+package policy
+
+import (
+	"testing"
+
+	"access_governance_system/internal/db/models"
+)
+
+func TestDecideEarly(t *testing.T) {
+	rulesWithBlocks := testRules
+	rulesWithBlocks.BlocksToReject = 1
+
+	tests := []struct {
+		name        string
+		rules       models.VotingRules
+		tally       Tally
+		wantStatus  models.ProposalStatus
+		wantDecided bool
+	}{
+		{
+			name:        "unanimous yes votes cannot be overcome",
+			rules:       testRules,
+			tally:       Tally{YesVotes: 6, VotedSeedersCount: 6, TotalSeedersCount: 10},
+			wantStatus:  models.ProposalStatusApproved,
+			wantDecided: true,
+		},
+		{
+			name:        "all voted",
+			rules:       testRules,
+			tally:       Tally{YesVotes: 1, NoVotes: 3, VotedSeedersCount: 4, TotalSeedersCount: 4},
+			wantStatus:  models.ProposalStatusRejected,
+			wantDecided: true,
+		},
+		{
+			name:        "remaining votes could flip",
+			rules:       testRules,
+			tally:       Tally{YesVotes: 2, NoVotes: 4, VotedSeedersCount: 6, TotalSeedersCount: 10},
+			wantStatus:  models.ProposalStatusRejected,
+			wantDecided: false,
+		},
+		{
+			name:        "abstentions do not protect an approval",
+			rules:       testRules,
+			tally:       Tally{YesVotes: 3, AbstainVotes: 2, VotedSeedersCount: 5, TotalSeedersCount: 10},
+			wantStatus:  models.ProposalStatusApproved,
+			wantDecided: false,
+		},
+		{
+			name:        "approval can still be blocked by the seeders who have not voted",
+			rules:       rulesWithBlocks,
+			tally:       Tally{YesVotes: 6, VotedSeedersCount: 6, TotalSeedersCount: 10},
+			wantStatus:  models.ProposalStatusApproved,
+			wantDecided: false,
+		},
+		{
+			name:        "approval with blocks enabled once everybody voted",
+			rules:       rulesWithBlocks,
+			tally:       Tally{YesVotes: 10, VotedSeedersCount: 10, TotalSeedersCount: 10},
+			wantStatus:  models.ProposalStatusApproved,
+			wantDecided: true,
+		},
+		{
+			name: "approval when the seeders who have not voted cannot block it",
+			rules: func() models.VotingRules {
+				rules := testRules
+				rules.BlocksToReject = 3
+				return rules
+			}(),
+			tally:       Tally{YesVotes: 8, VotedSeedersCount: 8, TotalSeedersCount: 10},
+			wantStatus:  models.ProposalStatusApproved,
+			wantDecided: true,
+		},
+		{
+			name:        "blocked",
+			rules:       rulesWithBlocks,
+			tally:       Tally{BlockVotes: 1, TotalSeedersCount: 10},
+			wantStatus:  models.ProposalStatusRejected,
+			wantDecided: true,
+		},
+		{
+			name:        "no quorum",
+			rules:       testRules,
+			tally:       Tally{NoVotes: 1, VotedSeedersCount: 1, TotalSeedersCount: 10},
+			wantStatus:  models.ProposalStatusNoQuorum,
+			wantDecided: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, decided := DecideEarly(NewDefaultPolicy(tt.rules), tt.tally)
+			if decision.Status != tt.wantStatus || decided != tt.wantDecided {
+				t.Errorf("DecideEarly() = %s, %t, want %s, %t", decision.Status, decided, tt.wantStatus, tt.wantDecided)
+			}
+			if decision.DecidedEarly != decided {
+				t.Errorf("DecidedEarly = %t, want %t", decision.DecidedEarly, decided)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package policy
+
+import (
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/services"
+)
+
+// NewProposalResult builds the result stored for a decided proposal.
+func NewProposalResult(proposal *models.Proposal, tally Tally, decision Decision, votes []services.Vote) *models.ProposalResult {
+	voterIDs := make([]int64, 0, len(votes))
+	for _, vote := range votes {
+		voterIDs = append(voterIDs, vote.UserID)
+	}
+
+	return &models.ProposalResult{
+		ProposalID:                    proposal.ID,
+		YesVotes:                      tally.YesVotes,
+		NoVotes:                       tally.NoVotes,
+		AbstainVotes:                  tally.AbstainVotes,
+		BlockVotes:                    tally.BlockVotes,
+		VotedSeedersCount:             tally.VotedSeedersCount,
+		TotalSeedersCount:             tally.TotalSeedersCount,
+		MinRequiredSeedersCount:       decision.Explanation.MinRequiredSeedersCount,
+		MinRequiredYesVotes:           decision.Explanation.MinRequiredYesVotes,
+		MinRequiredYesVotesToOverride: decision.Explanation.MinRequiredYesVotesToOverride,
+		VoterIDs:                      voterIDs,
+		DecidedEarly:                  decision.DecidedEarly,
+		Reason:                        decision.Reason,
+	}
+}
@@ -0,0 +1,36 @@
+package policy
+
+import (
+	"access_governance_system/configs"
+	"access_governance_system/internal/db/models"
+)
+
+// NewVotingRules returns the rules a new proposal for the nominee role is created with.
+func NewVotingRules(app configs.App, config configs.Policies, role models.NomineeRole) models.VotingRules {
+	policyConfig := config.Member
+	if role == models.NomineeRoleSeeder {
+		policyConfig = config.Seeder
+	}
+
+	return models.VotingRules{
+		VotingDurationDays:      app.VotingDurationDays,
+		VotingExtensionDays:     app.VotingExtensionDays,
+		MaxVotingExtensions:     app.MaxVotingExtensions,
+		Quorum:                  policyConfig.Quorum,
+		MaxRequiredSeedersCount: policyConfig.MaxRequiredSeedersCount,
+		MinYesVotesPercentage:   policyConfig.MinYesVotesPercentage,
+		MinRequiredYesVotes:     policyConfig.MinRequiredYesVotes,
+		YesVotesToOvercomeNo:    policyConfig.YesVotesToOvercomeNo,
+		BlocksToReject:          policyConfig.BlocksToReject,
+	}
+}
+
+// VotingRulesFor returns the rules the proposal was created with,
+// proposals created before the rules were snapshotted fall back to the current config.
+func VotingRulesFor(proposal *models.Proposal, app configs.App, config configs.Policies) models.VotingRules {
+	if proposal.VotingRules != nil {
+		return *proposal.VotingRules
+	}
+
+	return NewVotingRules(app, config, proposal.NomineeRole)
+}
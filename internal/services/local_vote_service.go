@@ -0,0 +1,71 @@
+package services
+
+import (
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/db/repositories"
+	"context"
+	"time"
+)
+
+type localVoteService struct {
+	api            VoteService
+	voteRepository repositories.VoteRepository
+}
+
+// NewLocalVoteService reads the votes stored from the vote webhook and leaves the polls to the vote API,
+// the votes are checked against the vote API, so the events the webhook missed are counted too.
+func NewLocalVoteService(api VoteService, voteRepository repositories.VoteRepository) VoteService {
+	return &localVoteService{
+		api:            api,
+		voteRepository: voteRepository,
+	}
+}
+
+func (s *localVoteService) CreatePoll(ctx context.Context, title, description string, dueDate time.Time) (models.Poll, error) {
+	return s.api.CreatePoll(ctx, title, description, dueDate)
+}
+
+func (s *localVoteService) GetVotes(ctx context.Context, pollID int) ([]Vote, error) {
+	votes, err := getStoredVotes(s.voteRepository, models.VoteSourceWebhook, pollID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The events of the poll may have been missed while the webhook was down or before the source was switched,
+	// counting only the stored votes then would miss the quorum, so the vote API wins when it knows more votes.
+	// The stored votes are counted while the vote API is unavailable, unless there are none.
+	apiVotes, err := s.api.GetVotes(ctx, pollID)
+	if err != nil {
+		if len(votes) == 0 {
+			return nil, err
+		}
+		return votes, nil
+	}
+
+	if len(apiVotes) > len(votes) {
+		return apiVotes, nil
+	}
+	return votes, nil
+}
+
+func (s *localVoteService) ExtendPoll(ctx context.Context, pollID int, dueDate time.Time) error {
+	return s.api.ExtendPoll(ctx, pollID, dueDate)
+}
+
+func (s *localVoteService) ClosePoll(ctx context.Context, pollID int) error {
+	return s.api.ClosePoll(ctx, pollID)
+}
+
+// getStoredVotes reads the votes of the poll of the source from the votes table.
+func getStoredVotes(voteRepository repositories.VoteRepository, source models.VoteSource, pollID int) ([]Vote, error) {
+	storedVotes, err := voteRepository.GetManyByPollID(source, pollID)
+	if err != nil {
+		return nil, err
+	}
+
+	votes := make([]Vote, 0, len(storedVotes))
+	for _, vote := range storedVotes {
+		votes = append(votes, Vote{UserID: vote.UserID, Option: vote.Option})
+	}
+	return votes, nil
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/db/repositories/mocks"
+
+	"go.uber.org/mock/gomock"
+)
+
+// stubVoteAPI answers GetVotes with the votes or the error, the polls are not used.
+type stubVoteAPI struct {
+	VoteService
+
+	votes []Vote
+	err   error
+}
+
+func (s *stubVoteAPI) GetVotes(ctx context.Context, pollID int) ([]Vote, error) {
+	return s.votes, s.err
+}
+
+func TestLocalVoteServiceGetVotes(t *testing.T) {
+	stored := []*models.Vote{
+		{Source: models.VoteSourceWebhook, PollID: 1, UserID: 1, Option: VoteOptionYes},
+		{Source: models.VoteSourceWebhook, PollID: 1, UserID: 2, Option: VoteOptionNo},
+	}
+
+	tests := []struct {
+		name      string
+		stored    []*models.Vote
+		api       *stubVoteAPI
+		wantVotes int
+		wantErr   error
+	}{
+		{
+			name:      "webhook missed votes",
+			stored:    stored,
+			api:       &stubVoteAPI{votes: []Vote{{UserID: 1}, {UserID: 2}, {UserID: 3}}},
+			wantVotes: 3,
+		},
+		{
+			name:      "webhook is up to date",
+			stored:    stored,
+			api:       &stubVoteAPI{votes: []Vote{{UserID: 1}, {UserID: 2}}},
+			wantVotes: 2,
+		},
+		{
+			name:      "vote api is behind",
+			stored:    stored,
+			api:       &stubVoteAPI{votes: []Vote{{UserID: 1}}},
+			wantVotes: 2,
+		},
+		{
+			name:      "vote api is unavailable",
+			stored:    stored,
+			api:       &stubVoteAPI{err: ErrUnavailable},
+			wantVotes: 2,
+		},
+		{
+			name:    "vote api is unavailable and no vote is stored",
+			api:     &stubVoteAPI{err: ErrUnavailable},
+			wantErr: ErrUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			voteRepository := mock_repositories.NewMockVoteRepository(ctrl)
+			voteRepository.EXPECT().GetManyByPollID(models.VoteSourceWebhook, 1).Return(tt.stored, nil)
+
+			votes, err := NewLocalVoteService(tt.api, voteRepository).GetVotes(context.Background(), 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if len(votes) != tt.wantVotes {
+				t.Errorf("got %d votes, want %d", len(votes), tt.wantVotes)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ package mock_services
 import (
 	models "access_governance_system/internal/db/models"
 	services "access_governance_system/internal/services"
+	context "context"
 	reflect "reflect"
 	time "time"
 
@@ -40,32 +41,60 @@ func (m *MockVoteService) EXPECT() *MockVoteServiceMockRecorder {
 	return m.recorder
 }
 
+// ClosePoll mocks base method.
+func (m *MockVoteService) ClosePoll(ctx context.Context, pollID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClosePoll", ctx, pollID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClosePoll indicates an expected call of ClosePoll.
+func (mr *MockVoteServiceMockRecorder) ClosePoll(ctx, pollID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePoll", reflect.TypeOf((*MockVoteService)(nil).ClosePoll), ctx, pollID)
+}
+
 // CreatePoll mocks base method.
-func (m *MockVoteService) CreatePoll(title, description string, dueDate time.Time) (models.Poll, error) {
+func (m *MockVoteService) CreatePoll(ctx context.Context, title, description string, dueDate time.Time) (models.Poll, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePoll", title, description, dueDate)
+	ret := m.ctrl.Call(m, "CreatePoll", ctx, title, description, dueDate)
 	ret0, _ := ret[0].(models.Poll)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreatePoll indicates an expected call of CreatePoll.
-func (mr *MockVoteServiceMockRecorder) CreatePoll(title, description, dueDate any) *gomock.Call {
+func (mr *MockVoteServiceMockRecorder) CreatePoll(ctx, title, description, dueDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePoll", reflect.TypeOf((*MockVoteService)(nil).CreatePoll), ctx, title, description, dueDate)
+}
+
+// ExtendPoll mocks base method.
+func (m *MockVoteService) ExtendPoll(ctx context.Context, pollID int, dueDate time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtendPoll", ctx, pollID, dueDate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExtendPoll indicates an expected call of ExtendPoll.
+func (mr *MockVoteServiceMockRecorder) ExtendPoll(ctx, pollID, dueDate any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePoll", reflect.TypeOf((*MockVoteService)(nil).CreatePoll), title, description, dueDate)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendPoll", reflect.TypeOf((*MockVoteService)(nil).ExtendPoll), ctx, pollID, dueDate)
 }
 
 // GetVotes mocks base method.
-func (m *MockVoteService) GetVotes(pollID int) ([]services.Vote, error) {
+func (m *MockVoteService) GetVotes(ctx context.Context, pollID int) ([]services.Vote, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetVotes", pollID)
+	ret := m.ctrl.Call(m, "GetVotes", ctx, pollID)
 	ret0, _ := ret[0].([]services.Vote)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetVotes indicates an expected call of GetVotes.
-func (mr *MockVoteServiceMockRecorder) GetVotes(pollID any) *gomock.Call {
+func (mr *MockVoteServiceMockRecorder) GetVotes(ctx, pollID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVotes", reflect.TypeOf((*MockVoteService)(nil).GetVotes), pollID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVotes", reflect.TypeOf((*MockVoteService)(nil).GetVotes), ctx, pollID)
 }
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"access_governance_system/internal"
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/db/repositories"
+	tgbot "access_governance_system/internal/tg_bot/extension"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	maxPollQuestionLength = 300
+
+	// messageNotModifiedError is what Telegram answers when a message is edited to the text it already has.
+	messageNotModifiedError = "message is not modified"
+)
+
+// voteOptionLabels are the texts of the options of a Telegram poll, in the order of VoteOptions.
+var voteOptionLabels = []string{"За", "Против", "Воздерживаюсь"}
+
+type telegramVoteService struct {
+	token                  string
+	chatID                 int64
+	telegramPollRepository repositories.TelegramPollRepository
+	voteRepository         repositories.VoteRepository
+}
+
+// NewTelegramVoteService posts native Telegram polls to the chat with the bot and counts the votes
+// the bot stored from the poll answers, see RecordPollAnswer.
+// The polls are not anonymous, the bot receives the answers only from such polls, so every seeder in the chat
+// sees who voted for which option.
+func NewTelegramVoteService(
+	token string,
+	chatID int64,
+	telegramPollRepository repositories.TelegramPollRepository,
+	voteRepository repositories.VoteRepository,
+) VoteService {
+	return &telegramVoteService{
+		token:                  token,
+		chatID:                 chatID,
+		telegramPollRepository: telegramPollRepository,
+		voteRepository:         voteRepository,
+	}
+}
+
+// CreatePoll posts the description and the poll replying to it. Telegram closes a poll by itself only within
+// 600 seconds of open_period or close_date, so the poll is posted without them, stays open and is stopped
+// by ClosePoll when the proposal is finalized.
+func (s *telegramVoteService) CreatePoll(ctx context.Context, title, description string, dueDate time.Time) (models.Poll, error) {
+	bot, err := tgbot.NewBotAPIWithContext(ctx, s.token)
+	if err != nil {
+		return models.Poll{}, fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+
+	descriptionMessage, err := bot.Send(tgbotapi.NewMessage(s.chatID, pollDescriptionText(description, dueDate)))
+	if err != nil {
+		return models.Poll{}, fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+
+	pollConfig := tgbotapi.NewPoll(s.chatID, truncate(title, maxPollQuestionLength), voteOptionLabels...)
+	pollConfig.IsAnonymous = false
+	pollConfig.ReplyToMessageID = descriptionMessage.MessageID
+
+	pollMessage, err := bot.Send(pollConfig)
+	if err != nil {
+		return models.Poll{}, fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+
+	telegramPoll, err := s.telegramPollRepository.Create(&models.TelegramPoll{
+		TelegramPollID:       pollMessage.Poll.ID,
+		ChatID:               s.chatID,
+		MessageID:            pollMessage.MessageID,
+		Description:          description,
+		DescriptionMessageID: descriptionMessage.MessageID,
+	})
+	if err != nil {
+		return models.Poll{}, err
+	}
+
+	return models.Poll{
+		ID:                  telegramPoll.ID,
+		ChatID:              int(s.chatID),
+		PollMessageID:       pollMessage.MessageID,
+		DiscussionMessageID: descriptionMessage.MessageID,
+	}, nil
+}
+
+func (s *telegramVoteService) GetVotes(ctx context.Context, pollID int) ([]Vote, error) {
+	return getStoredVotes(s.voteRepository, models.VoteSourceTelegram, pollID)
+}
+
+// ExtendPoll only updates the deadline in the description, the poll itself has no due date.
+// The description of a poll posted before it was stored is left as it is.
+func (s *telegramVoteService) ExtendPoll(ctx context.Context, pollID int, dueDate time.Time) error {
+	telegramPoll, err := s.telegramPollRepository.GetOneByID(pollID)
+	if err != nil {
+		return err
+	} else if telegramPoll == nil {
+		return fmt.Errorf("%w: telegram poll %d", ErrNotFound, pollID)
+	} else if telegramPoll.DescriptionMessageID == 0 {
+		return nil
+	}
+
+	bot, err := tgbot.NewBotAPIWithContext(ctx, s.token)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+
+	text := pollDescriptionText(telegramPoll.Description, dueDate)
+	_, err = bot.Request(tgbotapi.NewEditMessageText(telegramPoll.ChatID, telegramPoll.DescriptionMessageID, text))
+	if err != nil && !strings.Contains(err.Error(), messageNotModifiedError) {
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	return nil
+}
+
+func (s *telegramVoteService) ClosePoll(ctx context.Context, pollID int) error {
+	telegramPoll, err := s.telegramPollRepository.GetOneByID(pollID)
+	if err != nil {
+		return err
+	} else if telegramPoll == nil {
+		return fmt.Errorf("%w: telegram poll %d", ErrNotFound, pollID)
+	} else if telegramPoll.Closed {
+		return nil
+	}
+
+	bot, err := tgbot.NewBotAPIWithContext(ctx, s.token)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+
+	if _, err = bot.Request(tgbotapi.NewStopPoll(telegramPoll.ChatID, telegramPoll.MessageID)); err != nil {
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+
+	telegramPoll.Closed = true
+	return s.telegramPollRepository.Update(telegramPoll)
+}
+
+// RecordPollAnswer stores the answer to a poll posted by the Telegram backend, the answers to other polls
+// are ignored. Telegram sends no answer time, so the answers are ordered by the time they are received.
+func RecordPollAnswer(
+	answer *tgbotapi.PollAnswer,
+	telegramPollRepository repositories.TelegramPollRepository,
+	voteRepository repositories.VoteRepository,
+) error {
+	telegramPoll, err := telegramPollRepository.GetOneByTelegramPollID(answer.PollID)
+	if err != nil || telegramPoll == nil {
+		return err
+	}
+
+	option := ""
+	if len(answer.OptionIDs) > 0 {
+		optionID := answer.OptionIDs[0]
+		if optionID < 0 || optionID >= len(VoteOptions) {
+			return fmt.Errorf("unknown option %d of poll %s", optionID, answer.PollID)
+		}
+		option = VoteOptions[optionID]
+	}
+
+	return voteRepository.Save(&models.Vote{
+		Source:  models.VoteSourceTelegram,
+		PollID:  telegramPoll.ID,
+		UserID:  answer.User.ID,
+		Option:  option,
+		VotedAt: time.Now(),
+	})
+}
+
+func pollDescriptionText(description string, dueDate time.Time) string {
+	return fmt.Sprintf("%s\n\nГолосование продлится до %s.", description, internal.Format(dueDate))
+}
+
+func truncate(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return string(runes[:length-1]) + "…"
+}
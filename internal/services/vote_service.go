@@ -1,20 +1,45 @@
 package services
 
 import (
+	"access_governance_system/configs"
 	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/metrics"
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 )
 
+const (
+	VoteOptionYes     = "yes"
+	VoteOptionNo      = "no"
+	VoteOptionAbstain = "abstain"
+)
+
+// VoteOptions are the options every poll is created with, in the order they are shown.
+var VoteOptions = []string{VoteOptionYes, VoteOptionNo, VoteOptionAbstain}
+
+// The errors of the vote API, the returned errors wrap one of them.
+var (
+	ErrNotFound    = errors.New("vote api: not found")
+	ErrBadRequest  = errors.New("vote api: bad request")
+	ErrUnavailable = errors.New("vote api: unavailable")
+)
+
+// dueDateLayout keeps the offset, so the API reads the deadline in the community time zone it was set in.
+const dueDateLayout = time.RFC3339
+
 type poll struct {
-	Title       string `json:"name"`
-	Description string `json:"description"`
-	DueDate     string `json:"due_date"`
+	Title       string   `json:"name"`
+	Description string   `json:"description"`
+	DueDate     string   `json:"due_date"`
+	Options     []string `json:"options"`
 }
 
 type Vote struct {
@@ -25,84 +50,185 @@ type Vote struct {
 type service struct {
 	client  *http.Client
 	baseURL string
+	config  configs.VoteAPI
+	breaker *breaker
 }
 
+// VoteService is the contract of the vote API:
+//   - POST /poll with the title, the description, the due date and the options creates a poll and returns it;
+//     the due dates are sent in RFC 3339 with the offset of the community time zone, like 2024-01-08T12:00:00+03:00;
+//   - GET /vote?poll_id=<id> returns the current votes of the poll;
+//   - POST /poll/<id>/due_date with the new due date moves the due date of a poll which is not closed,
+//     the votes can be cast until the new due date even if the previous one has passed;
+//   - POST /poll/<id>/close closes the poll, after that no vote can be cast, changed or retracted
+//     and GET /vote keeps returning the final votes. Closing a closed poll succeeds.
+//
+// An unknown poll is answered with 404.
 type VoteService interface {
-	CreatePoll(title, description string, dueDate time.Time) (models.Poll, error)
-	GetVotes(pollID int) ([]Vote, error)
+	CreatePoll(ctx context.Context, title, description string, dueDate time.Time) (models.Poll, error)
+	GetVotes(ctx context.Context, pollID int) ([]Vote, error)
+	ExtendPoll(ctx context.Context, pollID int, dueDate time.Time) error
+	ClosePoll(ctx context.Context, pollID int) error
 }
 
-func NewVoteService(baseURL string) VoteService {
+// NewVoteService returns a client which times out every request, retries the reads while the API
+// is unavailable and stops calling it for a while after too many calls in a row failed.
+// The client keeps the state of its circuit breaker, so it is meant to be created once and shared.
+func NewVoteService(config configs.VoteAPI) VoteService {
 	return &service{
-		client:  &http.Client{},
-		baseURL: baseURL,
+		client:  &http.Client{Timeout: config.Timeout},
+		baseURL: config.URL,
+		config:  config,
+		breaker: newBreaker(config.BreakerThreshold, config.BreakerCooldown),
 	}
 }
 
-func (s *service) CreatePoll(title, description string, dueDate time.Time) (models.Poll, error) {
+func (s *service) CreatePoll(ctx context.Context, title, description string, dueDate time.Time) (_ models.Poll, err error) {
+	defer observeCall("create_poll", time.Now(), &err)
+
 	jsonData, err := json.Marshal(poll{
 		Title:       title,
 		Description: description,
-		DueDate:     dueDate.Format("2006-01-02T15:04:05"),
+		DueDate:     dueDate.Format(dueDateLayout),
+		Options:     VoteOptions,
 	})
 	if err != nil {
 		return models.Poll{}, err
 	}
 
-	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/%s", s.baseURL, "poll"), bytes.NewBuffer(jsonData))
+	// Creating a poll is not idempotent, so it is never retried.
+	responseBody, err := s.call(ctx, http.MethodPost, "poll", nil, jsonData, false)
 	if err != nil {
 		return models.Poll{}, err
 	}
 
-	request.Header.Add("Content-Type", "application/json; charset=utf-8")
-
-	fmt.Println(request.URL, string(jsonData))
-	response, err := s.client.Do(request)
-	if err != nil {
+	responseData := new(models.Poll)
+	if err = json.Unmarshal(responseBody, responseData); err != nil {
 		return models.Poll{}, err
 	}
 
-	responseBody, err := io.ReadAll(response.Body)
+	return *responseData, nil
+}
+
+func (s *service) GetVotes(ctx context.Context, pollID int) (_ []Vote, err error) {
+	defer observeCall("get_votes", time.Now(), &err)
+
+	query := url.Values{}
+	query.Add("poll_id", strconv.Itoa(pollID))
+
+	responseBody, err := s.call(ctx, http.MethodGet, "vote", query, nil, true)
 	if err != nil {
-		return models.Poll{}, err
+		return nil, err
 	}
 
-	responseData := new(models.Poll)
-	if err := json.Unmarshal(responseBody, responseData); err != nil {
-		return models.Poll{}, err
+	responseData := make([]Vote, 0)
+	if err = json.Unmarshal(responseBody, &responseData); err != nil {
+		return nil, err
 	}
 
-	return *responseData, nil
+	return responseData, nil
+}
+
+func (s *service) ExtendPoll(ctx context.Context, pollID int, dueDate time.Time) (err error) {
+	defer observeCall("extend_poll", time.Now(), &err)
+
+	jsonData, err := json.Marshal(struct {
+		DueDate string `json:"due_date"`
+	}{DueDate: dueDate.Format(dueDateLayout)})
+	if err != nil {
+		return err
+	}
+
+	// Setting the same due date again changes nothing, so it is retried.
+	_, err = s.call(ctx, http.MethodPost, fmt.Sprintf("poll/%d/due_date", pollID), nil, jsonData, true)
+	return err
+}
+
+func (s *service) ClosePoll(ctx context.Context, pollID int) (err error) {
+	defer observeCall("close_poll", time.Now(), &err)
+
+	_, err = s.call(ctx, http.MethodPost, fmt.Sprintf("poll/%d/close", pollID), nil, nil, false)
+	return err
+}
+
+// call makes the request through the circuit breaker, a retried request is made again
+// with a doubled delay while the API is unavailable and the context is not done.
+func (s *service) call(ctx context.Context, method, path string, query url.Values, body []byte, retry bool) ([]byte, error) {
+	delay := s.config.RetryDelay
+
+	for attempt := 0; ; attempt++ {
+		if !s.breaker.allow() {
+			return nil, fmt.Errorf("%w: circuit breaker is open", ErrUnavailable)
+		}
+
+		responseBody, err := s.do(ctx, method, path, query, body)
+		switch {
+		case ctx.Err() != nil:
+			s.breaker.cancelled()
+		case errors.Is(err, ErrUnavailable):
+			s.breaker.failed()
+		default:
+			s.breaker.succeeded()
+		}
+
+		if err == nil || !retry || !errors.Is(err, ErrUnavailable) || attempt >= s.config.MaxRetries {
+			return responseBody, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
 }
 
-func (s *service) GetVotes(pollID int) ([]Vote, error) {
-	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/%s", s.baseURL, "vote"), nil)
+func (s *service) do(ctx context.Context, method, path string, query url.Values, body []byte) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/%s", s.baseURL, path), bytes.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
 
-	query := request.URL.Query()
-	query.Add("poll_id", strconv.Itoa(pollID))
 	request.URL.RawQuery = query.Encode()
+	if body != nil {
+		request.Header.Add("Content-Type", "application/json; charset=utf-8")
+	}
 
 	response, err := s.client.Do(request)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
 	}
+	defer response.Body.Close()
 
 	responseBody, err := io.ReadAll(response.Body)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
 	}
 
-	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", response.StatusCode)
+	if err = statusError(response.StatusCode); err != nil {
+		return nil, fmt.Errorf("%w: %s /%s responded with %d: %s", err, method, path, response.StatusCode, responseBody)
 	}
 
-	responseData := make([]Vote, 0)
-	if err := json.Unmarshal(responseBody, &responseData); err != nil {
-		return nil, err
+	return responseBody, nil
+}
+
+// statusError maps a status code which is not a success to the error of the vote API,
+// too many requests are treated as unavailability, so they are retried.
+func statusError(statusCode int) error {
+	switch {
+	case statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices:
+		return nil
+	case statusCode == http.StatusNotFound:
+		return ErrNotFound
+	case statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError:
+		return ErrUnavailable
+	default:
+		return ErrBadRequest
 	}
+}
 
-	return responseData, nil
+// observeCall is deferred with a pointer to the named error, so it sees the error the call returns.
+func observeCall(operation string, started time.Time, err *error) {
+	metrics.ObserveVoteAPICall(operation, started, *err)
 }
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"access_governance_system/configs"
+)
+
+// scriptedServer answers the requests with the scripted status codes in order, repeating the last one,
+// a successful answer has the body.
+type scriptedServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	body     string
+	requests int
+}
+
+func newScriptedServer(t *testing.T, body string, statuses ...int) *scriptedServer {
+	s := &scriptedServer{statuses: statuses, body: body}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		status := s.statuses[len(s.statuses)-1]
+		if s.requests < len(s.statuses) {
+			status = s.statuses[s.requests]
+		}
+		s.requests++
+		s.mu.Unlock()
+
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			_, _ = w.Write([]byte(s.body))
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *scriptedServer) requestsCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func (s *scriptedServer) script(statuses ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statuses = statuses
+	s.requests = 0
+}
+
+func testVoteAPIConfig(url string) configs.VoteAPI {
+	return configs.VoteAPI{
+		URL:        url,
+		Timeout:    time.Second,
+		MaxRetries: 3,
+		RetryDelay: time.Millisecond,
+	}
+}
+
+func TestServiceRetries(t *testing.T) {
+	dueDate := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		body         string
+		statuses     []int
+		call         func(VoteService) error
+		wantRequests int
+		wantErr      error
+	}{
+		{
+			name:     "votes are retried until the api is available",
+			body:     `[{"user_id": 1, "option": "yes"}]`,
+			statuses: []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			call: func(s VoteService) error {
+				_, err := s.GetVotes(context.Background(), 1)
+				return err
+			},
+			wantRequests: 3,
+		},
+		{
+			name:     "votes are retried up to the max retries",
+			statuses: []int{http.StatusServiceUnavailable},
+			call: func(s VoteService) error {
+				_, err := s.GetVotes(context.Background(), 1)
+				return err
+			},
+			wantRequests: 4,
+			wantErr:      ErrUnavailable,
+		},
+		{
+			name:     "unknown poll is not retried",
+			statuses: []int{http.StatusNotFound},
+			call: func(s VoteService) error {
+				_, err := s.GetVotes(context.Background(), 1)
+				return err
+			},
+			wantRequests: 1,
+			wantErr:      ErrNotFound,
+		},
+		{
+			name:     "poll creation is never retried",
+			statuses: []int{http.StatusServiceUnavailable, http.StatusOK},
+			call: func(s VoteService) error {
+				_, err := s.CreatePoll(context.Background(), "title", "description", dueDate)
+				return err
+			},
+			wantRequests: 1,
+			wantErr:      ErrUnavailable,
+		},
+		{
+			name:     "closing a poll is not retried",
+			statuses: []int{http.StatusServiceUnavailable, http.StatusOK},
+			call: func(s VoteService) error {
+				return s.ClosePoll(context.Background(), 1)
+			},
+			wantRequests: 1,
+			wantErr:      ErrUnavailable,
+		},
+		{
+			name:     "moving the due date is retried as it is idempotent",
+			statuses: []int{http.StatusServiceUnavailable, http.StatusOK},
+			call: func(s VoteService) error {
+				return s.ExtendPoll(context.Background(), 1, dueDate)
+			},
+			wantRequests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newScriptedServer(t, tt.body, tt.statuses...)
+
+			err := tt.call(NewVoteService(testVoteAPIConfig(server.URL)))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got := server.requestsCount(); got != tt.wantRequests {
+				t.Errorf("made %d requests, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestServiceErrors(t *testing.T) {
+	tests := []struct {
+		status  int
+		wantErr error
+	}{
+		{status: http.StatusNotFound, wantErr: ErrNotFound},
+		{status: http.StatusBadRequest, wantErr: ErrBadRequest},
+		{status: http.StatusUnprocessableEntity, wantErr: ErrBadRequest},
+		{status: http.StatusTooManyRequests, wantErr: ErrUnavailable},
+		{status: http.StatusInternalServerError, wantErr: ErrUnavailable},
+		{status: http.StatusServiceUnavailable, wantErr: ErrUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			server := newScriptedServer(t, "", tt.status)
+
+			config := testVoteAPIConfig(server.URL)
+			config.MaxRetries = 0
+
+			_, err := NewVoteService(config).GetVotes(context.Background(), 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("connection refused", func(t *testing.T) {
+		server := newScriptedServer(t, "", http.StatusOK)
+		server.Close()
+
+		config := testVoteAPIConfig(server.URL)
+		config.MaxRetries = 0
+
+		_, err := NewVoteService(config).GetVotes(context.Background(), 1)
+		if !errors.Is(err, ErrUnavailable) {
+			t.Errorf("error = %v, want %v", err, ErrUnavailable)
+		}
+	})
+}
+
+func TestServiceBreaker(t *testing.T) {
+	server := newScriptedServer(t, `[]`, http.StatusServiceUnavailable)
+
+	config := testVoteAPIConfig(server.URL)
+	config.MaxRetries = 0
+	config.BreakerThreshold = 2
+	config.BreakerCooldown = 50 * time.Millisecond
+
+	service := NewVoteService(config)
+	getVotes := func() error {
+		_, err := service.GetVotes(context.Background(), 1)
+		return err
+	}
+
+	for i := 0; i < config.BreakerThreshold; i++ {
+		if err := getVotes(); !errors.Is(err, ErrUnavailable) {
+			t.Fatalf("call %d error = %v, want %v", i+1, err, ErrUnavailable)
+		}
+	}
+
+	// The breaker is open, the API is not called.
+	if err := getVotes(); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("error = %v, want %v", err, ErrUnavailable)
+	}
+	if got := server.requestsCount(); got != config.BreakerThreshold {
+		t.Errorf("made %d requests with the breaker open, want %d", got, config.BreakerThreshold)
+	}
+
+	// A failed probe after the cooldown opens the breaker again.
+	time.Sleep(config.BreakerCooldown)
+	server.script(http.StatusServiceUnavailable)
+
+	if err := getVotes(); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("probe error = %v, want %v", err, ErrUnavailable)
+	}
+	if err := getVotes(); !errors.Is(err, ErrUnavailable) || server.requestsCount() != 1 {
+		t.Errorf("after a failed probe made %d requests, want only the probe", server.requestsCount())
+	}
+
+	// A successful probe closes it.
+	time.Sleep(config.BreakerCooldown)
+	server.script(http.StatusOK)
+
+	for i := 0; i < 3; i++ {
+		if err := getVotes(); err != nil {
+			t.Errorf("call %d after the probe error = %v", i+1, err)
+		}
+	}
+	if got := server.requestsCount(); got != 3 {
+		t.Errorf("made %d requests with the breaker closed, want 3", got)
+	}
+}
+
+func TestBreakerHalfOpen(t *testing.T) {
+	b := newBreaker(1, 10*time.Millisecond)
+
+	b.failed()
+	if b.allow() {
+		t.Fatal("open breaker allows a call")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if !b.allow() {
+		t.Fatal("breaker does not allow a probe after the cooldown")
+	}
+	if b.allow() {
+		t.Error("breaker allows another call while the probe is in flight")
+	}
+
+	// A probe the caller gave up on tells nothing, the next call probes again.
+	b.cancelled()
+	if !b.allow() {
+		t.Error("breaker does not allow a probe after a cancelled one")
+	}
+
+	b.succeeded()
+	if !b.allow() || !b.allow() {
+		t.Error("closed breaker does not allow the calls")
+	}
+}
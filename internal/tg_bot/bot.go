@@ -1,6 +1,9 @@
 package tgbot
 
 import (
+	"context"
+
+	"access_governance_system/internal/metrics"
 	"access_governance_system/internal/tg_bot/handlers"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -12,14 +15,15 @@ type bot struct {
 }
 
 type Bot interface {
-	Start(token string, logger *zap.SugaredLogger)
+	Start(ctx context.Context, token string, logger *zap.SugaredLogger)
 }
 
 func NewBot(handler handlers.CommandHandler) Bot {
 	return &bot{handler: handler}
 }
 
-func (b *bot) Start(token string, logger *zap.SugaredLogger) {
+// Start handles the updates until the context is done, the update being handled is finished first.
+func (b *bot) Start(ctx context.Context, token string, logger *zap.SugaredLogger) {
 	logger.Info("creating bot")
 	bot, updates, err := b.createBot(token)
 	if err != nil {
@@ -27,9 +31,15 @@ func (b *bot) Start(token string, logger *zap.SugaredLogger) {
 	}
 	logger.Info("bot created")
 
+	go func() {
+		<-ctx.Done()
+		bot.StopReceivingUpdates()
+	}()
+
 	for update := range updates {
 		for _, message := range b.handler.Handle(bot, update) {
 			if _, err := bot.Send(message); err != nil {
+				metrics.TelegramSendFailed()
 				logger.Errorw("failed to send message", "error", err)
 			}
 		}
@@ -4,6 +4,7 @@ import (
 	"access_governance_system/configs"
 	"access_governance_system/internal/db/models"
 	"access_governance_system/internal/db/repositories"
+	"access_governance_system/internal/metrics"
 	"access_governance_system/internal/tg_bot/commands"
 	tgbot "access_governance_system/internal/tg_bot/extension"
 	"fmt"
@@ -97,6 +98,7 @@ func (c *addCommentCommand) handleWaitingForCommentState(comment string, user *m
 
 	_, err = bot.Send(message)
 	if err != nil {
+		metrics.TelegramSendFailed()
 		c.logger.Errorw("could not send message", "error", err)
 		return tgbot.DefaultErrorMessage(chatID)
 	}
@@ -7,8 +7,6 @@ import (
 	"access_governance_system/internal/tg_bot/commands"
 	tgbot "access_governance_system/internal/tg_bot/extension"
 	"fmt"
-	"strconv"
-	"strings"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"go.uber.org/zap"
@@ -17,14 +15,20 @@ import (
 const approvedProposalsCommandName = "approved_proposals"
 
 type approvedProposalsCommand struct {
-	proposalRepository repositories.ProposalRepository
-	logger             *zap.SugaredLogger
+	proposalRepository       repositories.ProposalRepository
+	proposalResultRepository repositories.ProposalResultRepository
+	logger                   *zap.SugaredLogger
 }
 
-func NewApprovedProposalsCommand(proposalRepository repositories.ProposalRepository, logger *zap.SugaredLogger) commands.Command {
+func NewApprovedProposalsCommand(
+	proposalRepository repositories.ProposalRepository,
+	proposalResultRepository repositories.ProposalResultRepository,
+	logger *zap.SugaredLogger,
+) commands.Command {
 	return &approvedProposalsCommand{
-		proposalRepository: proposalRepository,
-		logger:             logger,
+		proposalRepository:       proposalRepository,
+		proposalResultRepository: proposalResultRepository,
+		logger:                   logger,
 	}
 }
 
@@ -53,14 +57,22 @@ func (c *approvedProposalsCommand) Handle(command, arguments string, user *model
 		messageText += fmt.Sprintf("Дата окончания: %s\n", internal.Format(proposal.FinishedAt))
 		messageText += fmt.Sprintf("Результат: %s\n", proposal.Status.String())
 
+		if user.Role == models.UserRoleSeeder {
+			result, err := c.proposalResultRepository.GetOneByProposalID(proposal.ID)
+			if err != nil {
+				c.logger.Errorw("failed to get proposal result", "error", err, "proposal", proposal)
+			} else if result != nil {
+				messageText += fmt.Sprintf("Голоса (за:против:воздержались): %d:%d:%d\n", result.YesVotes, result.NoVotes, result.AbstainVotes)
+				messageText += fmt.Sprintf("Кворум: %d/%d\n", result.VotedSeedersCount, result.TotalSeedersCount)
+			}
+		}
+
 		message := tgbotapi.NewMessage(chatID, messageText)
 
 		if user.Role == models.UserRoleSeeder {
-			pollChatID := strings.TrimPrefix(strconv.Itoa(proposal.Poll.ChatID), "-100")
-
 			message.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
 				tgbotapi.NewInlineKeyboardRow(
-					tgbotapi.NewInlineKeyboardButtonURL("Посмотреть обсуждение", fmt.Sprintf("https://t.me/c/%s/%d", pollChatID, proposal.Poll.DiscussionMessageID)),
+					tgbotapi.NewInlineKeyboardButtonURL("Посмотреть обсуждение", tgbot.ChatMessageLink(proposal.Poll.ChatID, proposal.Poll.DiscussionMessageID)),
 				),
 			)
 		}
@@ -0,0 +1,187 @@
+package agbcommands
+
+import (
+	"access_governance_system/configs"
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/db/repositories"
+	"access_governance_system/internal/metrics"
+	"access_governance_system/internal/tg_bot/commands"
+	tgbot "access_governance_system/internal/tg_bot/extension"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	blockProposalCommandName = "block_proposal"
+
+	waitingForBlockReasonState = "waiting_for_block_reason"
+)
+
+type blockProposalCommand struct {
+	userRepository          repositories.UserRepository
+	proposalRepository      repositories.ProposalRepository
+	proposalBlockRepository repositories.ProposalBlockRepository
+	voteBotConfig           configs.Bot
+	logger                  *zap.SugaredLogger
+}
+
+func NewBlockProposalCommand(
+	userRepository repositories.UserRepository,
+	proposalRepository repositories.ProposalRepository,
+	proposalBlockRepository repositories.ProposalBlockRepository,
+	voteBotConfig configs.Bot,
+	logger *zap.SugaredLogger,
+) commands.Command {
+	return &blockProposalCommand{
+		userRepository:          userRepository,
+		proposalRepository:      proposalRepository,
+		proposalBlockRepository: proposalBlockRepository,
+		voteBotConfig:           voteBotConfig,
+		logger:                  logger,
+	}
+}
+
+func (c *blockProposalCommand) CanHandle(command string) bool {
+	return command == blockProposalCommandName
+}
+
+func (c *blockProposalCommand) Handle(command, arguments string, user *models.User, bot *tgbotapi.BotAPI, chatID int64) []tgbotapi.Chattable {
+	switch user.TelegramState.LastCommandState {
+	case "":
+		return []tgbotapi.Chattable{c.handleBlockProposalCommand(command, user, chatID)}
+	case waitingForBlockReasonState:
+		return []tgbotapi.Chattable{c.handleWaitingForBlockReasonState(command, user, chatID)}
+	default:
+		c.logger.Errorw("user has unknown state", "state", user.TelegramState.LastCommandState)
+		return []tgbotapi.Chattable{tgbot.DefaultErrorMessage(chatID)}
+	}
+}
+
+func (c *blockProposalCommand) handleBlockProposalCommand(command string, user *models.User, chatID int64) tgbotapi.Chattable {
+	if user.Role != models.UserRoleSeeder {
+		c.resetUser(user)
+		return tgbotapi.NewMessage(chatID, "Блокировать заявки могут только сидеры.")
+	}
+
+	parts := strings.Split(command, ":")
+	if len(parts) != 2 {
+		c.logger.Errorw("user has invalid command", "command", command)
+		return tgbot.DefaultErrorMessage(chatID)
+	}
+
+	proposalID, err := strconv.ParseInt(parts[1], 0, 64)
+	if err != nil {
+		c.logger.Errorw("could not get proposal id", "error", err)
+		return tgbot.DefaultErrorMessage(chatID)
+	}
+
+	proposal, err := c.proposalRepository.GetOneByID(proposalID)
+	if err != nil || proposal == nil {
+		c.logger.Errorw("could not get proposal", "error", err)
+		return tgbot.DefaultErrorMessage(chatID)
+	}
+
+	if proposal.Status != models.ProposalStatusCreated {
+		c.resetUser(user)
+		return tgbotapi.NewMessage(chatID, "Голосование по этой заявке уже завершено.")
+	}
+
+	// The button is not shown when the blocks are disabled, but the callback can still be sent.
+	if !blocksEnabled(proposal) {
+		c.resetUser(user)
+		return tgbotapi.NewMessage(chatID, "Эту заявку нельзя заблокировать.")
+	}
+
+	blocks, err := c.proposalBlockRepository.GetManyByProposalID(proposal.ID)
+	if err != nil {
+		c.logger.Errorw("could not get proposal blocks", "error", err)
+		return tgbot.DefaultErrorMessage(chatID)
+	}
+
+	for _, block := range blocks {
+		if block.UserID == user.ID {
+			c.resetUser(user)
+			return tgbotapi.NewMessage(chatID, "Ты уже заблокировал эту заявку.")
+		}
+	}
+
+	user.TempProposal = *proposal
+	user.TelegramState.LastCommandState = waitingForBlockReasonState
+	_ = c.updateUser(user)
+
+	text := fmt.Sprintf(
+		"Напиши, почему ты блокируешь кандидатуру %s (@%s). Причина будет анонимно показана остальным сидерам.",
+		proposal.NomineeName,
+		proposal.NomineeTelegramNickname,
+	)
+	return tgbotapi.NewMessage(chatID, text)
+}
+
+func (c *blockProposalCommand) handleWaitingForBlockReasonState(reason string, user *models.User, chatID int64) tgbotapi.Chattable {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return tgbotapi.NewMessage(chatID, "Блокировка без причины невозможна. Напиши, почему ты блокируешь эту заявку.")
+	}
+
+	// The proposal could have been finalized or withdrawn while the reason was typed.
+	proposal, err := c.proposalRepository.GetOneByID(int64(user.TempProposal.ID))
+	if err != nil || proposal == nil {
+		c.logger.Errorw("could not get proposal", "error", err)
+		return tgbot.DefaultErrorMessage(chatID)
+	}
+
+	if proposal.Status != models.ProposalStatusCreated {
+		c.resetUser(user)
+		return tgbotapi.NewMessage(chatID, "Голосование по этой заявке уже завершено, блокировка не добавлена.")
+	}
+
+	_, err = c.proposalBlockRepository.Create(&models.ProposalBlock{
+		ProposalID: user.TempProposal.ID,
+		UserID:     user.ID,
+		Reason:     reason,
+	})
+	if err != nil {
+		c.logger.Errorw("could not create proposal block", "error", err)
+		return tgbot.DefaultErrorMessage(chatID)
+	}
+
+	bot, err := tgbotapi.NewBotAPI(c.voteBotConfig.Token)
+	if err != nil {
+		c.logger.Errorw("could not create bot", "error", err)
+	} else {
+		text := fmt.Sprintf("Один из сидеров заблокировал эту заявку. Причина: %s", reason)
+		message := tgbotapi.NewMessage(int64(user.TempProposal.Poll.ChatID), text)
+		message.BaseChat.ReplyToMessageID = user.TempProposal.Poll.PollMessageID
+
+		if _, err = bot.Send(message); err != nil {
+			metrics.TelegramSendFailed()
+			c.logger.Errorw("could not send message", "error", err)
+		}
+	}
+
+	c.resetUser(user)
+
+	return tgbotapi.NewMessage(chatID, "Спасибо, твоя блокировка добавлена к заявке. Причина анонимно показана остальным сидерам.")
+}
+
+func blocksEnabled(proposal *models.Proposal) bool {
+	return proposal.VotingRules != nil && proposal.VotingRules.BlocksToReject > 0
+}
+
+func (c *blockProposalCommand) resetUser(user *models.User) {
+	user.TempProposal = models.Proposal{}
+	user.TelegramState = models.TelegramState{}
+	_ = c.updateUser(user)
+}
+
+func (c *blockProposalCommand) updateUser(user *models.User) error {
+	_, err := c.userRepository.Update(user)
+	if err != nil {
+		c.logger.Errorw("failed to update user", "error", err)
+	}
+	return err
+}
@@ -1,13 +1,18 @@
 package agbcommands
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"access_governance_system/configs"
+	"access_governance_system/internal"
 	"access_governance_system/internal/db/models"
 	"access_governance_system/internal/db/repositories"
+	"access_governance_system/internal/metrics"
+	"access_governance_system/internal/policy"
 	"access_governance_system/internal/services"
 	"access_governance_system/internal/tg_bot/commands"
 	tgbot "access_governance_system/internal/tg_bot/extension"
@@ -24,6 +29,9 @@ const (
 	waitingForNameState     = "waiting_for_name"
 	waitingForReasonState   = "waiting_for_reason"
 	waitingForConfirmState  = "waiting_for_confirm"
+
+	// voteServiceTimeout bounds a call to the vote service, so a hung vote API does not hang the bot update.
+	voteServiceTimeout = 30 * time.Second
 )
 
 var (
@@ -107,6 +115,7 @@ func (c *createProposalCommand) Handle(
 
 				_, err := bot.Send(tgbotapi.NewMessage(c.config.App.MembersChatID, text))
 				if err != nil {
+					metrics.TelegramSendFailed()
 					c.logger.Errorw("could not send message", "error", err)
 				}
 
@@ -196,7 +205,12 @@ func (c *createProposalCommand) handleWaitingForNicknameState(
 	if err != nil {
 		c.logger.Errorw("failed to get proposals by nominee nickname", "error", err)
 		return tgbot.DefaultErrorMessage(chatID)
-	} else if len(proposals) > 0 {
+	}
+
+	// A withdrawn proposal was never decided, so it does not count towards the cooldown.
+	proposals = withoutWithdrawnProposals(proposals)
+
+	if len(proposals) > 0 {
 		lastProposal := proposals[len(proposals)-1]
 
 		switch lastProposal.Status {
@@ -212,7 +226,7 @@ func (c *createProposalCommand) handleWaitingForNicknameState(
 				"Предыдущее предложение на добавление этого участника в сообщество ещё не рассмотрено.",
 			)
 		case models.ProposalStatusRejected:
-			if !lastProposal.CreatedAt.Before(time.Now().AddDate(0, -3, 0)) {
+			if cooldownEnd, _ := policy.CooldownEnd(lastProposal); time.Now().Before(cooldownEnd) {
 				c.logger.Warnf(
 					"user tried to create proposal for nominee with existing rejected proposal: %s, %d, %s",
 					proposalNomineeNickname,
@@ -313,6 +327,11 @@ func (c *createProposalCommand) handleWaitingForReasonState(
 ) tgbotapi.Chattable {
 	user.TempProposal.Comment = proposalDescription
 
+	voting := "Голосование проходит"
+	if c.config.Votes.Anonymous() {
+		voting += " анонимно"
+	}
+
 	text := fmt.Sprintf(
 		`
 Тип: *%s*
@@ -321,12 +340,13 @@ func (c *createProposalCommand) handleWaitingForReasonState(
 
 Все правильно, отправляем предложение на голосование?
 
-_Голосование проходит анонимно в группе из текущих активных участников (сидеры), которые являются носителями ДНК Shmit16. Решение будет принято в течение недели._
+_%s в группе из текущих активных участников (сидеры), которые являются носителями ДНК Shmit16. Решение будет принято в течение недели._
 `,
 		user.TempProposal.NomineeRole,
 		user.TempProposal.NomineeName,
 		user.TempProposal.NomineeTelegramNickname,
 		user.TempProposal.Comment,
+		voting,
 	)
 
 	message := tgbotapi.NewMessage(chatID, text)
@@ -357,7 +377,7 @@ func (c *createProposalCommand) handleWaitingForConfirmState(
 	}
 
 	createdAt := time.Now()
-	finishedAt := createdAt.AddDate(0, 0, c.config.App.VotingDurationDays)
+	finishedAt := internal.VotingDeadline(createdAt, c.config.App.VotingDurationDays, c.config.App.Timezone.Location)
 
 	var description string
 
@@ -386,17 +406,33 @@ func (c *createProposalCommand) handleWaitingForConfirmState(
 		)
 	}
 
+	// Everything the proposal is stored with is gathered first, so a failure does not leave a poll without a proposal.
+	seeders, err := c.userRepository.GetManyByRole(models.UserRoleSeeder)
+	if err != nil {
+		c.logger.Errorw("failed to get seeders", "error", err)
+		return tgbot.DefaultErrorMessage(chatID)
+	}
+
+	votingRules := policy.NewVotingRules(c.config.App, c.config.Policies, user.TempProposal.NomineeRole)
+
 	title := user.TempProposal.NomineeName
 
-	dueDate := time.Date(finishedAt.Year(), finishedAt.Month(), finishedAt.Day(), 12, 0, 0, 0, finishedAt.Location())
-	poll, err := c.voteService.CreatePoll(title, description, dueDate)
-	if err != nil {
+	ctx, cancel := context.WithTimeout(context.Background(), voteServiceTimeout)
+	defer cancel()
+
+	poll, err := c.voteService.CreatePoll(ctx, title, description, finishedAt)
+	if errors.Is(err, services.ErrUnavailable) {
+		c.logger.Errorw("vote api is unavailable", "error", err)
+		return tgbot.ErrorMessage(chatID, "Сервис голосования сейчас недоступен, подтверди заявку еще раз через несколько минут")
+	} else if err != nil {
 		c.logger.Errorw("failed to create poll", "error", err)
 		return tgbot.DefaultErrorMessage(chatID)
 	}
 
 	user.TempProposal.CreatedAt = createdAt
 	user.TempProposal.FinishedAt = finishedAt
+	user.TempProposal.VotingRules = &votingRules
+	user.TempProposal.SeedersCount = len(seeders)
 
 	if poll != (models.Poll{}) {
 		user.TempProposal.Poll = poll
@@ -405,6 +441,13 @@ func (c *createProposalCommand) handleWaitingForConfirmState(
 	proposal, err := c.proposalRepository.Create(&user.TempProposal)
 	if err != nil {
 		c.logger.Errorw("failed to create proposal", "error", err)
+
+		closeCtx, closeCancel := context.WithTimeout(context.Background(), voteServiceTimeout)
+		defer closeCancel()
+
+		if err = c.voteService.ClosePoll(closeCtx, poll.ID); err != nil {
+			c.logger.Errorw("failed to close poll of not created proposal", "error", err, "poll", poll)
+		}
 		return tgbot.DefaultErrorMessage(chatID)
 	}
 
@@ -430,3 +473,13 @@ func (c *createProposalCommand) updateUser(user *models.User) error {
 	}
 	return err
 }
+
+func withoutWithdrawnProposals(proposals []*models.Proposal) []*models.Proposal {
+	filtered := make([]*models.Proposal, 0, len(proposals))
+	for _, proposal := range proposals {
+		if proposal.Status != models.ProposalStatusWithdrawn {
+			filtered = append(filtered, proposal)
+		}
+	}
+	return filtered
+}
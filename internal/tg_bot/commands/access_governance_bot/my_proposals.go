@@ -0,0 +1,170 @@
+package agbcommands
+
+import (
+	"access_governance_system/internal"
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/db/repositories"
+	"access_governance_system/internal/policy"
+	"access_governance_system/internal/tg_bot/commands"
+	tgbot "access_governance_system/internal/tg_bot/extension"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	myProposalsCommandName = "my_proposals"
+
+	myProposalsPageSize = 5
+)
+
+var proposalStatusTexts = map[models.ProposalStatus]string{
+	models.ProposalStatusCreated:   "на рассмотрении",
+	models.ProposalStatusApproved:  "принято",
+	models.ProposalStatusRejected:  "отклонено",
+	models.ProposalStatusNoQuorum:  "кворум не состоялся",
+	models.ProposalStatusWithdrawn: "отозвано",
+}
+
+type myProposalsCommand struct {
+	userRepository           repositories.UserRepository
+	proposalResultRepository repositories.ProposalResultRepository
+	logger                   *zap.SugaredLogger
+}
+
+func NewMyProposalsCommand(
+	userRepository repositories.UserRepository,
+	proposalResultRepository repositories.ProposalResultRepository,
+	logger *zap.SugaredLogger,
+) commands.Command {
+	return &myProposalsCommand{
+		userRepository:           userRepository,
+		proposalResultRepository: proposalResultRepository,
+		logger:                   logger,
+	}
+}
+
+func (c *myProposalsCommand) CanHandle(command string) bool {
+	return command == myProposalsCommandName
+}
+
+// Handle shows a page of the proposals the user filed, newest first, the page is passed as my_proposals:<page>.
+func (c *myProposalsCommand) Handle(command, arguments string, user *models.User, bot *tgbotapi.BotAPI, chatID int64) []tgbotapi.Chattable {
+	page := 1
+
+	if command != myProposalsCommandName {
+		parts := strings.Split(command, ":")
+		if len(parts) != 2 || parts[0] != myProposalsCommandName {
+			c.logger.Errorw("user has invalid command", "command", command)
+			return []tgbotapi.Chattable{tgbot.DefaultErrorMessage(chatID)}
+		}
+
+		var err error
+		page, err = strconv.Atoi(parts[1])
+		if err != nil {
+			c.logger.Errorw("could not get page", "error", err)
+			return []tgbotapi.Chattable{tgbot.DefaultErrorMessage(chatID)}
+		}
+	}
+
+	user.TelegramState.LastCommand = ""
+
+	_, err := c.userRepository.Update(user)
+	if err != nil {
+		c.logger.Errorw("failed to update user", "error", err)
+	}
+
+	if len(user.Proposals) == 0 {
+		return []tgbotapi.Chattable{tgbotapi.NewMessage(chatID, "Ты еще не создавал предложений. Выбери команду /create_proposal для создания нового.")}
+	}
+
+	proposals := make([]models.Proposal, len(user.Proposals))
+	copy(proposals, user.Proposals)
+
+	sort.Slice(proposals, func(i, j int) bool {
+		return proposals[i].CreatedAt.After(proposals[j].CreatedAt)
+	})
+
+	pagesCount := (len(proposals) + myProposalsPageSize - 1) / myProposalsPageSize
+	if page < 1 || page > pagesCount {
+		page = 1
+	}
+
+	start := (page - 1) * myProposalsPageSize
+	end := start + myProposalsPageSize
+	if end > len(proposals) {
+		end = len(proposals)
+	}
+
+	texts := make([]string, 0, end-start)
+	for i := range proposals[start:end] {
+		texts = append(texts, c.proposalText(&proposals[start+i]))
+	}
+
+	messageText := fmt.Sprintf("Твои предложения (страница %d из %d):\n\n%s", page, pagesCount, strings.Join(texts, "\n"))
+	message := tgbotapi.NewMessage(chatID, messageText)
+
+	if pagesCount > 1 {
+		var buttons []tgbotapi.InlineKeyboardButton
+
+		if page > 1 {
+			buttons = append(buttons, tgbotapi.NewInlineKeyboardButtonData("← Назад", fmt.Sprintf("%s:%d", myProposalsCommandName, page-1)))
+		}
+		if page < pagesCount {
+			buttons = append(buttons, tgbotapi.NewInlineKeyboardButtonData("Вперед →", fmt.Sprintf("%s:%d", myProposalsCommandName, page+1)))
+		}
+
+		message.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(buttons...))
+	}
+
+	return []tgbotapi.Chattable{message}
+}
+
+func (c *myProposalsCommand) proposalText(proposal *models.Proposal) string {
+	var text string
+
+	text += fmt.Sprintf("Участник: %s (@%s)\n", proposal.NomineeName, proposal.NomineeTelegramNickname)
+	text += fmt.Sprintf("Статус: %s\n", proposalStatusText(proposal.Status))
+	text += fmt.Sprintf("Дата начала: %s\n", internal.Format(proposal.CreatedAt))
+	text += fmt.Sprintf("Дата окончания: %s\n", internal.Format(proposal.FinishedAt))
+
+	switch proposal.Status {
+	case models.ProposalStatusCreated:
+		text += fmt.Sprintf("Осталось дней: %d\n", daysLeft(proposal.FinishedAt))
+	case models.ProposalStatusApproved, models.ProposalStatusRejected, models.ProposalStatusNoQuorum:
+		result, err := c.proposalResultRepository.GetOneByProposalID(proposal.ID)
+		if err != nil {
+			c.logger.Errorw("failed to get proposal result", "error", err, "proposal", proposal)
+		} else if result != nil {
+			text += fmt.Sprintf("Голоса (за:против:воздержались): %d:%d:%d\n", result.YesVotes, result.NoVotes, result.AbstainVotes)
+		}
+
+		if cooldownEnd, ok := policy.CooldownEnd(proposal); ok {
+			text += fmt.Sprintf("Повторная заявка возможна с: %s\n", internal.Format(cooldownEnd))
+		}
+	}
+
+	return text
+}
+
+func proposalStatusText(status models.ProposalStatus) string {
+	if text, ok := proposalStatusTexts[status]; ok {
+		return text
+	}
+	return status.String()
+}
+
+// daysLeft counts a started day as a whole one, so the last day of the voting shows 1.
+func daysLeft(finishedAt time.Time) int {
+	days := int(math.Ceil(time.Until(finishedAt).Hours() / 24))
+	if days < 0 {
+		return 0
+	}
+	return days
+}
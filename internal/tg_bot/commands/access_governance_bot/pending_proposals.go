@@ -7,8 +7,6 @@ import (
 	"access_governance_system/internal/tg_bot/commands"
 	tgbot "access_governance_system/internal/tg_bot/extension"
 	"fmt"
-	"strconv"
-	"strings"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"go.uber.org/zap"
@@ -70,6 +68,10 @@ func (c *pendingProposalsCommand) Handle(command, arguments string, user *models
 		messageText += fmt.Sprintf("Дата начала: %s\n", internal.Format(proposal.CreatedAt))
 		messageText += fmt.Sprintf("Дата окончания: %s\n", internal.Format(proposal.FinishedAt))
 
+		if proposal.ExtensionsCount > 0 {
+			messageText += fmt.Sprintf("Голосование продлено из-за отсутствия кворума: %d раз(а)\n", proposal.ExtensionsCount)
+		}
+
 		var message tgbotapi.MessageConfig
 
 		switch user.Role {
@@ -89,15 +91,21 @@ func (c *pendingProposalsCommand) Handle(command, arguments string, user *models
 				),
 			)
 		case models.UserRoleSeeder:
-			pollChatID := strings.TrimPrefix(strconv.Itoa(proposal.Poll.ChatID), "-100")
-
-			message = tgbotapi.NewMessage(chatID, messageText)
-			message.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+			rows := [][]tgbotapi.InlineKeyboardButton{
 				tgbotapi.NewInlineKeyboardRow(
-					tgbotapi.NewInlineKeyboardButtonURL("Проголосовать", fmt.Sprintf("https://t.me/c/%s/%d", pollChatID, proposal.Poll.PollMessageID)),
-					tgbotapi.NewInlineKeyboardButtonURL("Обсудить", fmt.Sprintf("https://t.me/c/%s/%d", pollChatID, proposal.Poll.DiscussionMessageID)),
+					tgbotapi.NewInlineKeyboardButtonURL("Проголосовать", tgbot.ChatMessageLink(proposal.Poll.ChatID, proposal.Poll.PollMessageID)),
+					tgbotapi.NewInlineKeyboardButtonURL("Обсудить", tgbot.ChatMessageLink(proposal.Poll.ChatID, proposal.Poll.DiscussionMessageID)),
 				),
-			)
+			}
+
+			if blocksEnabled(proposal) {
+				rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+					tgbotapi.NewInlineKeyboardButtonData("Заблокировать", fmt.Sprintf("%s:%d", blockProposalCommandName, proposal.ID)),
+				))
+			}
+
+			message = tgbotapi.NewMessage(chatID, messageText)
+			message.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
 		}
 
 		messages = append(messages, message)
@@ -0,0 +1,216 @@
+package agbcommands
+
+import (
+	"access_governance_system/internal"
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/db/repositories"
+	"access_governance_system/internal/outbox"
+	"access_governance_system/internal/services"
+	"access_governance_system/internal/tg_bot/commands"
+	tgbot "access_governance_system/internal/tg_bot/extension"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	withdrawProposalCommandName = "withdraw_proposal"
+
+	waitingForWithdrawConfirmState = "waiting_for_withdraw_confirm"
+
+	withdrawConfirmYes = "Да, отозвать"
+	withdrawConfirmNo  = "Нет"
+)
+
+type withdrawProposalCommand struct {
+	userRepository     repositories.UserRepository
+	proposalRepository repositories.ProposalRepository
+	voteService        services.VoteService
+	logger             *zap.SugaredLogger
+}
+
+func NewWithdrawProposalCommand(
+	userRepository repositories.UserRepository,
+	proposalRepository repositories.ProposalRepository,
+	voteService services.VoteService,
+	logger *zap.SugaredLogger,
+) commands.Command {
+	return &withdrawProposalCommand{
+		userRepository:     userRepository,
+		proposalRepository: proposalRepository,
+		voteService:        voteService,
+		logger:             logger,
+	}
+}
+
+func (c *withdrawProposalCommand) CanHandle(command string) bool {
+	return command == withdrawProposalCommandName
+}
+
+func (c *withdrawProposalCommand) Handle(command, arguments string, user *models.User, bot *tgbotapi.BotAPI, chatID int64) []tgbotapi.Chattable {
+	if command == withdrawProposalCommandName {
+		return c.handleWithdrawProposalCommand(user, chatID)
+	}
+
+	switch user.TelegramState.LastCommandState {
+	case "":
+		return []tgbotapi.Chattable{c.handleProposalChosen(command, user, chatID)}
+	case waitingForWithdrawConfirmState:
+		return []tgbotapi.Chattable{c.handleWaitingForWithdrawConfirmState(command, user, chatID)}
+	default:
+		c.logger.Errorw("user has unknown state", "state", user.TelegramState.LastCommandState)
+		return []tgbotapi.Chattable{tgbot.DefaultErrorMessage(chatID)}
+	}
+}
+
+func (c *withdrawProposalCommand) handleWithdrawProposalCommand(user *models.User, chatID int64) []tgbotapi.Chattable {
+	proposals, err := c.proposalRepository.GetManyByNominatorID(user.ID, models.ProposalStatusCreated)
+	if err != nil {
+		c.logger.Errorw("failed to get proposals", "error", err)
+		return []tgbotapi.Chattable{tgbot.DefaultErrorMessage(chatID)}
+	}
+
+	if len(proposals) == 0 {
+		c.resetUser(user)
+		return []tgbotapi.Chattable{tgbotapi.NewMessage(chatID, "У тебя нет предложений на рассмотрении.")}
+	}
+
+	messages := make([]tgbotapi.Chattable, 0, len(proposals))
+
+	for _, proposal := range proposals {
+		text := fmt.Sprintf(
+			"Участник: %s (@%s)\nДата начала: %s\nДата окончания: %s",
+			proposal.NomineeName,
+			proposal.NomineeTelegramNickname,
+			internal.Format(proposal.CreatedAt),
+			internal.Format(proposal.FinishedAt),
+		)
+
+		message := tgbotapi.NewMessage(chatID, text)
+		message.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData("Отозвать", fmt.Sprintf("%s:%d", withdrawProposalCommandName, proposal.ID)),
+			),
+		)
+
+		messages = append(messages, message)
+	}
+
+	return messages
+}
+
+func (c *withdrawProposalCommand) handleProposalChosen(command string, user *models.User, chatID int64) tgbotapi.Chattable {
+	parts := strings.Split(command, ":")
+	if len(parts) != 2 || parts[0] != withdrawProposalCommandName {
+		c.logger.Errorw("user has invalid command", "command", command)
+		return tgbot.DefaultErrorMessage(chatID)
+	}
+
+	proposalID, err := strconv.ParseInt(parts[1], 0, 64)
+	if err != nil {
+		c.logger.Errorw("could not get proposal id", "error", err)
+		return tgbot.DefaultErrorMessage(chatID)
+	}
+
+	proposal, err := c.proposalRepository.GetOneByID(proposalID)
+	if err != nil || proposal == nil {
+		c.logger.Errorw("could not get proposal", "error", err)
+		return tgbot.DefaultErrorMessage(chatID)
+	}
+
+	if proposal.NominatorID != user.ID {
+		c.logger.Warnw("user tried to withdraw proposal of another nominator", "proposal_id", proposal.ID, "user_id", user.ID)
+		c.resetUser(user)
+		return tgbotapi.NewMessage(chatID, "Отозвать можно только свое предложение.")
+	}
+
+	if proposal.Status != models.ProposalStatusCreated {
+		c.resetUser(user)
+		return tgbotapi.NewMessage(chatID, "Голосование по этой заявке уже завершено.")
+	}
+
+	user.TempProposal = *proposal
+	user.TelegramState.LastCommand = withdrawProposalCommandName
+	user.TelegramState.LastCommandState = waitingForWithdrawConfirmState
+	_ = c.updateUser(user)
+
+	text := fmt.Sprintf(
+		"Отозвать кандидатуру %s (@%s)? Голосование будет остановлено, сидеры получат уведомление.",
+		proposal.NomineeName,
+		proposal.NomineeTelegramNickname,
+	)
+
+	message := tgbotapi.NewMessage(chatID, text)
+	message.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(withdrawConfirmYes, withdrawConfirmYes),
+			tgbotapi.NewInlineKeyboardButtonData(withdrawConfirmNo, withdrawConfirmNo),
+		),
+	)
+	return message
+}
+
+func (c *withdrawProposalCommand) handleWaitingForWithdrawConfirmState(confirmation string, user *models.User, chatID int64) tgbotapi.Chattable {
+	if confirmation != withdrawConfirmYes {
+		c.resetUser(user)
+		return tgbotapi.NewMessage(chatID, "Предложение осталось на рассмотрении.")
+	}
+
+	proposal := user.TempProposal
+	c.resetUser(user)
+
+	withdrawnProposal, err := c.proposalRepository.Withdraw(&proposal, []*models.OutboxMessage{
+		outbox.NewMessage(messageForProposalWithdrawnToSeedersGroup(&proposal)),
+	})
+	if errors.Is(err, repositories.ErrProposalAlreadyFinished) {
+		return tgbotapi.NewMessage(chatID, "Голосование по этой заявке уже завершено.")
+	} else if err != nil || withdrawnProposal == nil {
+		c.logger.Errorw("failed to withdraw proposal", "error", err)
+		return tgbot.DefaultErrorMessage(chatID)
+	}
+
+	c.logger.Infow("proposal withdrawn", "proposal_id", withdrawnProposal.ID)
+
+	// The proposal state service closes the polls left open, so a failure here is only logged.
+	ctx, cancel := context.WithTimeout(context.Background(), voteServiceTimeout)
+	defer cancel()
+
+	err = c.voteService.ClosePoll(ctx, withdrawnProposal.Poll.ID)
+	if err != nil && !errors.Is(err, services.ErrNotFound) {
+		c.logger.Errorw("failed to close poll", "error", err, "proposal_id", withdrawnProposal.ID)
+	} else if err = c.proposalRepository.MarkPollClosed(withdrawnProposal); err != nil {
+		c.logger.Errorw("failed to mark poll closed", "error", err, "proposal_id", withdrawnProposal.ID)
+	}
+
+	return tgbotapi.NewMessage(chatID, "Предложение отозвано, голосование остановлено.")
+}
+
+func messageForProposalWithdrawnToSeedersGroup(proposal *models.Proposal) tgbotapi.MessageConfig {
+	text := fmt.Sprintf(
+		"Кандидатура %s (@%s) отозвана автором предложения, голосование остановлено.",
+		proposal.NomineeName,
+		proposal.NomineeTelegramNickname,
+	)
+	message := tgbotapi.NewMessage(int64(proposal.Poll.ChatID), text)
+	message.BaseChat.ReplyToMessageID = proposal.Poll.PollMessageID
+	return message
+}
+
+func (c *withdrawProposalCommand) resetUser(user *models.User) {
+	user.TempProposal = models.Proposal{}
+	user.TelegramState = models.TelegramState{}
+	_ = c.updateUser(user)
+}
+
+func (c *withdrawProposalCommand) updateUser(user *models.User) error {
+	_, err := c.userRepository.Update(user)
+	if err != nil {
+		c.logger.Errorw("failed to update user", "error", err)
+	}
+	return err
+}
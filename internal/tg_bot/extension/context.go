@@ -0,0 +1,24 @@
+package extension
+
+import (
+	"context"
+	"net/http"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// NewBotAPIWithContext creates a bot whose requests, getMe included, are cancelled with ctx,
+// so it is meant to be used for the calls made while ctx is alive only.
+func NewBotAPIWithContext(ctx context.Context, token string) (*tgbotapi.BotAPI, error) {
+	client := &http.Client{Transport: contextTransport{ctx: ctx}}
+	return tgbotapi.NewBotAPIWithClient(token, tgbotapi.APIEndpoint, client)
+}
+
+// contextTransport binds the requests of a client which does not take a context to one.
+type contextTransport struct {
+	ctx context.Context
+}
+
+func (t contextTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	return http.DefaultTransport.RoundTrip(request.WithContext(t.ctx))
+}
@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
@@ -43,3 +46,7 @@ func CreateChatInviteLink(
 
 	return "", errors.New("could not create invite link")
 }
+
+func ChatMessageLink(chatID, messageID int) string {
+	return fmt.Sprintf("https://t.me/c/%s/%d", strings.TrimPrefix(strconv.Itoa(chatID), "-100"), messageID)
+}
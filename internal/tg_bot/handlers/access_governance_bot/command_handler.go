@@ -4,11 +4,13 @@ import (
 	"access_governance_system/configs"
 	"access_governance_system/internal/db/models"
 	"access_governance_system/internal/db/repositories"
+	"access_governance_system/internal/metrics"
 	"access_governance_system/internal/tg_bot/commands"
 	tgbot "access_governance_system/internal/tg_bot/extension"
 	"access_governance_system/internal/tg_bot/handlers"
 	"fmt"
 	"strings"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"go.uber.org/zap"
@@ -175,6 +177,8 @@ func (h *accessGovernanceBotCommandHandler) createUserIfNeeded(telegramUser *tgb
 func (h *accessGovernanceBotCommandHandler) tryToHandleCommand(command string, commands []commands.Command, user *models.User, bot *tgbotapi.BotAPI, chatID int64) []tgbotapi.Chattable {
 	for _, handler := range commands {
 		if handler.CanHandle(command) {
+			defer metrics.ObserveCommand(command, time.Now())
+
 			user.TempProposal = models.Proposal{}
 			user.TelegramState = models.TelegramState{LastCommand: command}
 
@@ -196,6 +200,8 @@ func (h *accessGovernanceBotCommandHandler) tryToHandleSubCommand(command, subCo
 
 	for _, handler := range commands {
 		if handler.CanHandle(command) {
+			defer metrics.ObserveCommand(command, time.Now())
+
 			responseMessage := handler.Handle(subCommand, "", user, bot, chatID)
 			if responseMessage == nil {
 				h.logger.Errorw("failed to handle subcommand", "subCommand", subCommand)
@@ -221,6 +227,8 @@ func (h *accessGovernanceBotCommandHandler) tryToHandleQueryCallback(query strin
 
 	for _, handler := range commands {
 		if handler.CanHandle(command) {
+			defer metrics.ObserveCommand(command, time.Now())
+
 			user.TelegramState.LastCommand = query
 
 			user, err := h.userRepository.Update(user)
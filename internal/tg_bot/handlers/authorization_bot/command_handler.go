@@ -1,9 +1,12 @@
 package abhandlers
 
 import (
+	"time"
+
 	"access_governance_system/configs"
 	"access_governance_system/internal/db/models"
 	"access_governance_system/internal/db/repositories"
+	"access_governance_system/internal/metrics"
 	"access_governance_system/internal/tg_bot/commands"
 	"access_governance_system/internal/tg_bot/extension"
 	"access_governance_system/internal/tg_bot/handlers"
@@ -80,6 +83,7 @@ func (h *authorizationBotCommandHandler) tryToHandleCommand(message *tgbotapi.Me
 
 	for _, handler := range commands {
 		if handler.CanHandle(command) {
+			defer metrics.ObserveCommand(command, time.Now())
 			return handler.Handle(command, arguments, user, bot, chatID)
 		}
 	}
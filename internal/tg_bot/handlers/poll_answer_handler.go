@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"access_governance_system/internal/db/repositories"
+	"access_governance_system/internal/services"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+type pollAnswerHandler struct {
+	next                   CommandHandler
+	telegramPollRepository repositories.TelegramPollRepository
+	voteRepository         repositories.VoteRepository
+	logger                 *zap.SugaredLogger
+}
+
+// NewPollAnswerHandler records the answers to the native Telegram polls and passes the other updates to next.
+func NewPollAnswerHandler(
+	next CommandHandler,
+	telegramPollRepository repositories.TelegramPollRepository,
+	voteRepository repositories.VoteRepository,
+	logger *zap.SugaredLogger,
+) CommandHandler {
+	return &pollAnswerHandler{
+		next:                   next,
+		telegramPollRepository: telegramPollRepository,
+		voteRepository:         voteRepository,
+		logger:                 logger,
+	}
+}
+
+func (h *pollAnswerHandler) Handle(bot *tgbotapi.BotAPI, update tgbotapi.Update) []tgbotapi.Chattable {
+	if update.PollAnswer == nil {
+		return h.next.Handle(bot, update)
+	}
+
+	if err := services.RecordPollAnswer(update.PollAnswer, h.telegramPollRepository, h.voteRepository); err != nil {
+		h.logger.Errorw("failed to record poll answer", "error", err, "poll_id", update.PollAnswer.PollID)
+	}
+	return []tgbotapi.Chattable{}
+}
@@ -0,0 +1,131 @@
+// Package webhook receives the vote events the vote API posts, so the votes are known without polling it.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/db/repositories"
+	"access_governance_system/internal/services"
+	"go.uber.org/zap"
+)
+
+const (
+	// SignatureHeader carries "sha256=" and the hex HMAC-SHA256 of the body with the shared secret.
+	SignatureHeader = "X-Signature-256"
+
+	signaturePrefix = "sha256="
+	maxBodySize     = 1 << 20
+)
+
+// VoteEvent is a vote cast or changed in a poll, an empty option means the vote was retracted.
+type VoteEvent struct {
+	PollID  int       `json:"poll_id"`
+	UserID  int64     `json:"user_id"`
+	Option  string    `json:"option"`
+	VotedAt time.Time `json:"voted_at"`
+}
+
+type voteHandler struct {
+	secret         []byte
+	voteRepository repositories.VoteRepository
+	logger         *zap.SugaredLogger
+}
+
+// NewVoteHandler stores the vote events which are signed with the secret and rejects the others with 401.
+func NewVoteHandler(secret string, voteRepository repositories.VoteRepository, logger *zap.SugaredLogger) http.Handler {
+	return &voteHandler{
+		secret:         []byte(secret),
+		voteRepository: voteRepository,
+		logger:         logger,
+	}
+}
+
+// Sign returns the signature header value of the body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (h *voteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !h.verify(r.Header.Get(SignatureHeader), body) {
+		h.logger.Warnw("vote event with an invalid signature", "remote_addr", r.RemoteAddr)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	var event VoteEvent
+	if err = json.Unmarshal(body, &event); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if event.PollID <= 0 || event.UserID == 0 || !isKnownOption(event.Option) {
+		http.Error(w, "poll_id, user_id or option is invalid", http.StatusBadRequest)
+		return
+	}
+
+	if event.VotedAt.IsZero() {
+		event.VotedAt = time.Now()
+	}
+
+	err = h.voteRepository.Save(&models.Vote{
+		Source:  models.VoteSourceWebhook,
+		PollID:  event.PollID,
+		UserID:  event.UserID,
+		Option:  event.Option,
+		VotedAt: event.VotedAt,
+	})
+	if err != nil {
+		h.logger.Errorw("failed to save vote", "error", err, "event", event)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *voteHandler) verify(signature string, body []byte) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+
+	expected := Sign(string(h.secret), body)
+	return hmac.Equal([]byte(signature), []byte(expected))
+}
+
+func isKnownOption(option string) bool {
+	if option == "" {
+		return true
+	}
+	for _, o := range services.VoteOptions {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"access_governance_system/internal/db/models"
+	"access_governance_system/internal/db/repositories/mocks"
+
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+const testSecret = "secret"
+
+func TestVoteHandler(t *testing.T) {
+	const event = `{"poll_id": 1, "user_id": 123, "option": "yes", "voted_at": "2026-03-01T12:00:00Z"}`
+
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		signature  string
+		wantStatus int
+		wantSaved  bool
+	}{
+		{
+			name:       "signed event",
+			body:       event,
+			signature:  Sign(testSecret, []byte(event)),
+			wantStatus: http.StatusNoContent,
+			wantSaved:  true,
+		},
+		{
+			name:       "retracted vote",
+			body:       `{"poll_id": 1, "user_id": 123, "option": ""}`,
+			signature:  Sign(testSecret, []byte(`{"poll_id": 1, "user_id": 123, "option": ""}`)),
+			wantStatus: http.StatusNoContent,
+			wantSaved:  true,
+		},
+		{
+			name:       "signed with another secret",
+			body:       event,
+			signature:  Sign("another secret", []byte(event)),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "signature of another body",
+			body:       event,
+			signature:  Sign(testSecret, []byte(`{"poll_id": 2}`)),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "signature without the prefix",
+			body:       event,
+			signature:  strings.TrimPrefix(Sign(testSecret, []byte(event)), signaturePrefix),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "no signature",
+			body:       event,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "body over the limit",
+			body:       strings.Repeat(" ", maxBodySize) + event,
+			signature:  Sign(testSecret, []byte(strings.Repeat(" ", maxBodySize)+event)),
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "unknown option",
+			body:       `{"poll_id": 1, "user_id": 123, "option": "maybe"}`,
+			signature:  Sign(testSecret, []byte(`{"poll_id": 1, "user_id": 123, "option": "maybe"}`)),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "not a post",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			voteRepository := mock_repositories.NewMockVoteRepository(ctrl)
+
+			if tt.wantSaved {
+				voteRepository.EXPECT().Save(gomock.Any()).DoAndReturn(func(vote *models.Vote) error {
+					if vote.Source != models.VoteSourceWebhook || vote.PollID != 1 || vote.UserID != 123 || vote.VotedAt.IsZero() {
+						t.Errorf("saved vote %+v, want the webhook vote of user 123 in poll 1", vote)
+					}
+					return nil
+				})
+			}
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			request := httptest.NewRequest(method, "/votes", strings.NewReader(tt.body))
+			if tt.signature != "" {
+				request.Header.Set(SignatureHeader, tt.signature)
+			}
+			recorder := httptest.NewRecorder()
+
+			NewVoteHandler(testSecret, voteRepository, zap.NewNop().Sugar()).ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestVoteHandlerVotedAt(t *testing.T) {
+	body := `{"poll_id": 1, "user_id": 123, "option": "no", "voted_at": "2026-03-01T12:00:00Z"}`
+
+	ctrl := gomock.NewController(t)
+	voteRepository := mock_repositories.NewMockVoteRepository(ctrl)
+	voteRepository.EXPECT().Save(gomock.Any()).DoAndReturn(func(vote *models.Vote) error {
+		// The order of the events is the time they were cast at, not the time they are received at.
+		if want := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC); !vote.VotedAt.Equal(want) {
+			t.Errorf("VotedAt = %s, want %s", vote.VotedAt, want)
+		}
+		return nil
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/votes", strings.NewReader(body))
+	request.Header.Set(SignatureHeader, Sign(testSecret, []byte(body)))
+	recorder := httptest.NewRecorder()
+
+	NewVoteHandler(testSecret, voteRepository, zap.NewNop().Sugar()).ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", recorder.Code, http.StatusNoContent)
+	}
+}
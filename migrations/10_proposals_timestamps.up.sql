@@ -0,0 +1,5 @@
+ALTER TABLE proposals
+    ALTER COLUMN created_at DROP DEFAULT,
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at::TIMESTAMP AT TIME ZONE 'UTC',
+    ALTER COLUMN created_at SET DEFAULT now(),
+    ALTER COLUMN finished_at TYPE TIMESTAMPTZ USING (finished_at + TIME '12:00') AT TIME ZONE 'UTC';
@@ -0,0 +1,20 @@
+CREATE TYPE OutboxMessageStatus AS ENUM ('pending', 'sent', 'failed');
+
+CREATE TABLE IF NOT EXISTS outbox_messages (
+    id SERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL,
+    text VARCHAR NOT NULL,
+    parse_mode VARCHAR NOT NULL DEFAULT '',
+    reply_to_message_id INTEGER NOT NULL DEFAULT 0,
+    disable_web_page_preview BOOLEAN NOT NULL DEFAULT FALSE,
+    reply_markup JSONB,
+    invite_link JSONB,
+    status OutboxMessageStatus NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error VARCHAR NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    sent_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS outbox_messages_pending_idx ON outbox_messages (next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,15 @@
+CREATE TYPE JobRunStatus AS ENUM ('succeeded', 'failed');
+
+CREATE TABLE IF NOT EXISTS job_runs (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    status JobRunStatus NOT NULL,
+    processed INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    skipped INTEGER NOT NULL DEFAULT 0,
+    error VARCHAR NOT NULL DEFAULT '',
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS job_runs_name_status_idx ON job_runs (name, status, finished_at DESC);
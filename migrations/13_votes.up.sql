@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS votes (
+    id SERIAL PRIMARY KEY,
+    poll_id INTEGER NOT NULL,
+    user_id BIGINT NOT NULL,
+    option VARCHAR NOT NULL DEFAULT '',
+    voted_at TIMESTAMPTZ NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (poll_id, user_id)
+);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS telegram_polls (
+    id SERIAL PRIMARY KEY,
+    telegram_poll_id VARCHAR NOT NULL UNIQUE,
+    chat_id BIGINT NOT NULL,
+    message_id INTEGER NOT NULL,
+    closed BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
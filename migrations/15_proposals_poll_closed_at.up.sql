@@ -0,0 +1,4 @@
+ALTER TABLE proposals ADD COLUMN IF NOT EXISTS poll_closed_at TIMESTAMPTZ;
+
+-- The polls of the proposals finished before are past their due date already.
+UPDATE proposals SET poll_closed_at = finished_at WHERE status <> 'created';
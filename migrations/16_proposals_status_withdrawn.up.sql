@@ -0,0 +1,2 @@
+-- The .up.sql migrations run outside of a transaction, so the value can be added here.
+ALTER TYPE ProposalStatus ADD VALUE IF NOT EXISTS 'withdrawn';
@@ -0,0 +1,3 @@
+ALTER TABLE telegram_polls
+    ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS description_message_id INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,14 @@
+-- The webhook votes are keyed by the poll ids of the vote API and the Telegram votes by telegram_polls.id,
+-- so the two id spaces are kept apart by the source.
+ALTER TABLE votes ADD COLUMN IF NOT EXISTS source VARCHAR NOT NULL DEFAULT 'webhook';
+
+-- The stored votes do not tell their source, the ones received for a Telegram poll after it was posted
+-- are taken as its answers, as the source is only switched while no proposal is being voted on.
+UPDATE votes SET source = 'telegram'
+FROM telegram_polls
+WHERE votes.source = 'webhook'
+  AND votes.poll_id = telegram_polls.id
+  AND votes.received_at >= telegram_polls.created_at;
+
+ALTER TABLE votes DROP CONSTRAINT IF EXISTS votes_poll_id_user_id_key;
+CREATE UNIQUE INDEX IF NOT EXISTS votes_source_poll_id_user_id_key ON votes (source, poll_id, user_id);
@@ -0,0 +1,3 @@
+ALTER TABLE proposals
+    ADD COLUMN IF NOT EXISTS voting_rules JSONB,
+    ADD COLUMN IF NOT EXISTS seeders_count INTEGER NOT NULL DEFAULT 0;
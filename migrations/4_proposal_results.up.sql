@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS proposal_results (
+    id SERIAL PRIMARY KEY,
+    proposal_id INTEGER NOT NULL UNIQUE REFERENCES proposals (id),
+    yes_votes INTEGER NOT NULL DEFAULT 0,
+    no_votes INTEGER NOT NULL DEFAULT 0,
+    abstain_votes INTEGER NOT NULL DEFAULT 0,
+    voted_seeders_count INTEGER NOT NULL DEFAULT 0,
+    total_seeders_count INTEGER NOT NULL DEFAULT 0,
+    min_required_seeders_count INTEGER NOT NULL DEFAULT 0,
+    min_required_yes_votes INTEGER NOT NULL DEFAULT 0,
+    min_required_yes_votes_to_override INTEGER NOT NULL DEFAULT 0,
+    voter_ids BIGINT[] NOT NULL DEFAULT '{}'::BIGINT[],
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,2 @@
+ALTER TABLE proposal_results
+    ADD COLUMN IF NOT EXISTS decided_early BOOLEAN NOT NULL DEFAULT FALSE;
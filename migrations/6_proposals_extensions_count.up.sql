@@ -0,0 +1,2 @@
+ALTER TABLE proposals
+    ADD COLUMN IF NOT EXISTS extensions_count INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS proposal_reminders (
+    id SERIAL PRIMARY KEY,
+    proposal_id INTEGER NOT NULL REFERENCES proposals (id),
+    user_id INTEGER NOT NULL REFERENCES users (id),
+    offset_days INTEGER NOT NULL,
+    extensions_count INTEGER NOT NULL DEFAULT 0,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (proposal_id, user_id, offset_days, extensions_count)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS proposal_blocks (
+    id SERIAL PRIMARY KEY,
+    proposal_id INTEGER NOT NULL REFERENCES proposals (id),
+    user_id INTEGER NOT NULL REFERENCES users (id),
+    reason VARCHAR NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (proposal_id, user_id)
+);
+
+ALTER TABLE proposal_results
+    ADD COLUMN IF NOT EXISTS block_votes INTEGER NOT NULL DEFAULT 0;
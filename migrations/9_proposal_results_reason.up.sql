@@ -0,0 +1,2 @@
+ALTER TABLE proposal_results
+    ADD COLUMN IF NOT EXISTS reason VARCHAR NOT NULL DEFAULT '';